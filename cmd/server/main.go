@@ -10,7 +10,8 @@ import (
 	"syscall"
 	"time"
 
-	"memo/internal/models"
+	"memo/internal/models/factory"
+	"memo/internal/models/sqlite"
 	"memo/internal/router"
 )
 
@@ -21,11 +22,24 @@ func main() {
 		log.Fatal("Error parsing templates:", err)
 	}
 
-	// Initialize store with SQLite database
-	store, err := models.NewTodoStore("todos.db")
+	// DB_DRIVER picks the storage backend, defaulting to sqlite; DB_PATH
+	// (a file path for sqlite/bitcask/pogreb, a DSN for postgres) is
+	// passed through to it. See factory.Open.
+	backend, err := factory.Open(os.Getenv("DB_DRIVER"), os.Getenv("DB_PATH"))
 	if err != nil {
 		log.Fatal("Error initializing database:", err)
 	}
+
+	// Auth, SSE and the option-taking search the HTTP layer calls exist
+	// only on *sqlite.Store (see models.Store's doc comment), so this
+	// server only runs on the sqlite backend. DB_DRIVER values naming a
+	// different backend are for embedders calling factory.Open directly
+	// to get a bare models.Store, not for this server.
+	store, ok := backend.(*sqlite.Store)
+	if !ok {
+		backend.Close()
+		log.Fatalf("DB_DRIVER=%q is not supported by this server: it requires the sqlite backend for auth, search and SSE", os.Getenv("DB_DRIVER"))
+	}
 	defer store.Close()
 
 	// Setup routes
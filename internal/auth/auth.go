@@ -0,0 +1,36 @@
+// Package auth holds the small pieces shared between the router and the
+// handlers for threading the authenticated user through a request:
+// context helpers and opaque bearer token generation.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type contextKey string
+
+const userIDKey contextKey = "userID"
+
+// WithUserID returns a copy of ctx carrying the authenticated user's ID.
+func WithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// UserIDFromContext returns the authenticated user's ID stashed by the
+// Authenticate middleware, if any.
+func UserIDFromContext(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	return userID, ok
+}
+
+// GenerateToken returns a random opaque bearer token suitable for storing
+// alongside a user record and handing back to the client.
+func GenerateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,108 @@
+// Package events provides a small in-memory pub/sub broker that lets HTTP
+// handlers (in particular the SSE endpoint) observe todo mutations made
+// through sqlite.Store without polling.
+package events
+
+import "sync"
+
+type EventType string
+
+const (
+	EventAdd    EventType = "add"
+	EventToggle EventType = "toggle"
+	EventDelete EventType = "delete"
+)
+
+// Event describes a single todo mutation. ID is a monotonically
+// increasing sequence number scoped to the broker, used to support
+// Last-Event-ID replay. Todo holds a models.Todo; it's typed as
+// interface{} here so this package doesn't have to import models.
+type Event struct {
+	ID     int64
+	Type   EventType
+	Todo   interface{}
+	UserID int
+}
+
+// ringBufferSize bounds how many past events Broker keeps around for
+// Last-Event-ID replay; older events are simply dropped.
+const ringBufferSize = 256
+
+// Broker fans published events out to subscribers, scoped per user so one
+// user's SSE stream never observes another user's todos.
+type Broker struct {
+	mu          sync.Mutex
+	nextID      int64
+	subscribers map[chan Event]int // channel -> userID
+	buffer      []Event
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: make(map[chan Event]int),
+	}
+}
+
+// Subscribe registers a new listener for userID's events. The returned
+// func unsubscribes and closes the channel; callers must call it
+// (typically via defer) once they stop reading.
+func (b *Broker) Subscribe(userID int) (<-chan Event, func()) {
+	ch := make(chan Event, 16)
+
+	b.mu.Lock()
+	b.subscribers[ch] = userID
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+		b.mu.Unlock()
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish fans evt out to every subscriber belonging to userID and
+// records it in the replay buffer.
+func (b *Broker) Publish(userID int, evt Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	evt.ID = b.nextID
+	evt.UserID = userID
+
+	b.buffer = append(b.buffer, evt)
+	if len(b.buffer) > ringBufferSize {
+		b.buffer = b.buffer[len(b.buffer)-ringBufferSize:]
+	}
+
+	for ch, subUserID := range b.subscribers {
+		if subUserID != userID {
+			continue
+		}
+		select {
+		case ch <- evt:
+		default:
+			// Slow subscriber; drop the event rather than block publishers.
+		}
+	}
+}
+
+// Replay returns buffered events for userID with an ID greater than
+// lastEventID, in order, for resuming a stream after reconnect.
+func (b *Broker) Replay(userID int, lastEventID int64) []Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var missed []Event
+	for _, evt := range b.buffer {
+		if evt.UserID == userID && evt.ID > lastEventID {
+			missed = append(missed, evt)
+		}
+	}
+	return missed
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// apiError is the structured error body returned by the /api/v1 surface.
+type apiError struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, apiError{Error: msg})
+}
+
+// wantsJSON reports whether the caller asked for a JSON representation,
+// either by hitting the /api/v1 surface or by sending an Accept header
+// that names application/json and does not name text/html (q-values are
+// not weighed).
+func wantsJSON(r *http.Request) bool {
+	if strings.HasPrefix(r.URL.Path, "/api/v1/") {
+		return true
+	}
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "application/json") && !strings.Contains(accept, "text/html")
+}
+
+// APITodosHandler serves GET /api/v1/todos (list) and POST /api/v1/todos
+// (create), returning JSON regardless of Accept.
+func (h *TodoHandler) APITodosHandler(w http.ResponseWriter, r *http.Request) {
+	uid, ok := userID(r)
+	if !ok {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		todos, err := h.store.GetTodos(uid)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "error retrieving todos")
+			return
+		}
+		writeJSON(w, http.StatusOK, todos)
+
+	case http.MethodPost:
+		var body struct {
+			Text string `json:"text"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+
+		todo, err := h.store.AddTodo(uid, body.Text)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		w.Header().Set("Location", "/api/v1/todos/"+strconv.Itoa(todo.ID))
+		writeJSON(w, http.StatusCreated, todo)
+
+	default:
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// APITodoHandler serves PATCH /api/v1/todos/{id} (toggle and/or text edit)
+// and DELETE /api/v1/todos/{id}.
+func (h *TodoHandler) APITodoHandler(w http.ResponseWriter, r *http.Request) {
+	uid, ok := userID(r)
+	if !ok {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/todos/")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid todo ID")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPatch:
+		var body struct {
+			Completed *bool   `json:"completed"`
+			Text      *string `json:"text"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+
+		if body.Text != nil {
+			if err := h.store.UpdateTodoText(uid, id, *body.Text); err != nil {
+				apiStoreError(w, err)
+				return
+			}
+		}
+		if body.Completed != nil {
+			if err := h.store.SetCompleted(uid, id, *body.Completed); err != nil {
+				apiStoreError(w, err)
+				return
+			}
+		}
+
+		todos, err := h.store.GetTodos(uid)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "error retrieving todos")
+			return
+		}
+		for _, todo := range todos {
+			if todo.ID == id {
+				writeJSON(w, http.StatusOK, todo)
+				return
+			}
+		}
+		writeJSONError(w, http.StatusNotFound, "todo not found")
+
+	case http.MethodDelete:
+		if err := h.store.DeleteTodo(uid, id); err != nil {
+			apiStoreError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// apiStoreError translates a sqlite.Store error into the matching
+// JSON error response.
+func apiStoreError(w http.ResponseWriter, err error) {
+	if err.Error() == "todo not found" {
+		writeJSONError(w, http.StatusNotFound, "todo not found")
+		return
+	}
+	writeJSONError(w, http.StatusBadRequest, err.Error())
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"memo/internal/auth"
+	"memo/internal/models"
+)
+
+func TestAPITodosHandler(t *testing.T) {
+	handler, cleanup := setupTestHandler(t)
+	defer cleanup()
+
+	t.Run("creates a todo and returns 201 with Location", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/todos", strings.NewReader(`{"text":"New todo"}`))
+		req, _ = asUser(t, handler.store, req, "api-create@example.com")
+		w := httptest.NewRecorder()
+
+		handler.APITodosHandler(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d", http.StatusCreated, w.Code)
+		}
+		if w.Header().Get("Location") == "" {
+			t.Error("Expected a Location header")
+		}
+
+		var todo models.Todo
+		if err := json.Unmarshal(w.Body.Bytes(), &todo); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if todo.Text != "New todo" {
+			t.Errorf("Expected text %q, got %q", "New todo", todo.Text)
+		}
+	})
+
+	t.Run("rejects invalid todo text with a structured error", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/todos", strings.NewReader(`{"text":""}`))
+		req, _ = asUser(t, handler.store, req, "api-create-bad@example.com")
+		w := httptest.NewRecorder()
+
+		handler.APITodosHandler(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+
+		var body apiError
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if body.Error == "" {
+			t.Error("Expected a non-empty error message")
+		}
+	})
+
+	t.Run("lists todos as JSON", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/todos", nil)
+		req, userID := asUser(t, handler.store, req, "api-list@example.com")
+		handler.store.AddTodo(userID, "Existing todo")
+
+		w := httptest.NewRecorder()
+		handler.APITodosHandler(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		var todos []models.Todo
+		if err := json.Unmarshal(w.Body.Bytes(), &todos); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(todos) != 1 {
+			t.Errorf("Expected 1 todo, got %d", len(todos))
+		}
+	})
+}
+
+func TestAPITodoHandler(t *testing.T) {
+	handler, cleanup := setupTestHandler(t)
+	defer cleanup()
+
+	t.Run("toggles completed via PATCH", func(t *testing.T) {
+		setupReq := httptest.NewRequest("GET", "/", nil)
+		_, userID := asUser(t, handler.store, setupReq, "api-patch@example.com")
+		todo, _ := handler.store.AddTodo(userID, "Test todo")
+
+		req := httptest.NewRequest("PATCH", "/api/v1/todos/"+strconv.Itoa(todo.ID), strings.NewReader(`{"completed":true}`))
+		req = req.WithContext(auth.WithUserID(req.Context(), userID))
+		w := httptest.NewRecorder()
+
+		handler.APITodoHandler(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		var updated models.Todo
+		json.Unmarshal(w.Body.Bytes(), &updated)
+		if !updated.Completed {
+			t.Error("Expected todo to be completed")
+		}
+	})
+
+	t.Run("setting completed to its current value is idempotent", func(t *testing.T) {
+		setupReq := httptest.NewRequest("GET", "/", nil)
+		_, userID := asUser(t, handler.store, setupReq, "api-patch-idempotent@example.com")
+		todo, _ := handler.store.AddTodo(userID, "Test todo")
+		handler.store.ToggleTodo(userID, todo.ID) // already completed
+
+		req := httptest.NewRequest("PATCH", "/api/v1/todos/"+strconv.Itoa(todo.ID), strings.NewReader(`{"completed":true}`))
+		req = req.WithContext(auth.WithUserID(req.Context(), userID))
+		w := httptest.NewRecorder()
+
+		handler.APITodoHandler(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		var updated models.Todo
+		json.Unmarshal(w.Body.Bytes(), &updated)
+		if !updated.Completed {
+			t.Error("Expected todo to remain completed, PATCH with completed:true should not toggle it off")
+		}
+	})
+
+	t.Run("edits text via PATCH", func(t *testing.T) {
+		setupReq := httptest.NewRequest("GET", "/", nil)
+		_, userID := asUser(t, handler.store, setupReq, "api-patch-text@example.com")
+		todo, _ := handler.store.AddTodo(userID, "Original")
+
+		req := httptest.NewRequest("PATCH", "/api/v1/todos/"+strconv.Itoa(todo.ID), strings.NewReader(`{"text":"Revised"}`))
+		req = req.WithContext(auth.WithUserID(req.Context(), userID))
+		w := httptest.NewRecorder()
+
+		handler.APITodoHandler(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		var updated models.Todo
+		json.Unmarshal(w.Body.Bytes(), &updated)
+		if updated.Text != "Revised" {
+			t.Errorf("Expected text %q, got %q", "Revised", updated.Text)
+		}
+	})
+
+	t.Run("deletes via DELETE and returns 204", func(t *testing.T) {
+		setupReq := httptest.NewRequest("GET", "/", nil)
+		_, userID := asUser(t, handler.store, setupReq, "api-delete@example.com")
+		todo, _ := handler.store.AddTodo(userID, "Test todo")
+
+		req := httptest.NewRequest("DELETE", "/api/v1/todos/"+strconv.Itoa(todo.ID), nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), userID))
+		w := httptest.NewRecorder()
+
+		handler.APITodoHandler(w, req)
+
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("Expected status %d, got %d", http.StatusNoContent, w.Code)
+		}
+	})
+
+	t.Run("returns a structured 404 for a missing todo", func(t *testing.T) {
+		setupReq := httptest.NewRequest("GET", "/", nil)
+		_, userID := asUser(t, handler.store, setupReq, "api-missing@example.com")
+
+		req := httptest.NewRequest("DELETE", "/api/v1/todos/99999", nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), userID))
+		w := httptest.NewRecorder()
+
+		handler.APITodoHandler(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+}
+
+func TestTodosHandlerContentNegotiation(t *testing.T) {
+	handler, cleanup := setupTestHandler(t)
+	defer cleanup()
+
+	setupReq := httptest.NewRequest("GET", "/todos", nil)
+	_, userID := asUser(t, handler.store, setupReq, "negotiate@example.com")
+	handler.store.AddTodo(userID, "Test todo")
+
+	t.Run("returns HTML by default", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/todos", nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), userID))
+		w := httptest.NewRecorder()
+
+		handler.TodosHandler(w, req)
+
+		if !strings.Contains(w.Body.String(), "<div>") {
+			t.Error("Expected an HTML fragment")
+		}
+	})
+
+	t.Run("returns JSON when Accept prefers it", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/todos", nil)
+		req.Header.Set("Accept", "application/json")
+		req = req.WithContext(auth.WithUserID(req.Context(), userID))
+		w := httptest.NewRecorder()
+
+		handler.TodosHandler(w, req)
+
+		var todos []models.Todo
+		if err := json.Unmarshal(w.Body.Bytes(), &todos); err != nil {
+			t.Fatalf("Expected valid JSON response: %v", err)
+		}
+	})
+}
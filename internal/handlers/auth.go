@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"memo/internal/models/sqlite"
+)
+
+// sessionCookieName is the cookie LoginHandler sets so HTMX browser
+// clients don't have to attach an Authorization header by hand.
+const sessionCookieName = "session_token"
+
+// AuthHandler exposes the registration/login surface backed by the same
+// store as TodoHandler.
+type AuthHandler struct {
+	store *sqlite.Store
+}
+
+func NewAuthHandler(store *sqlite.Store) *AuthHandler {
+	return &AuthHandler{store: store}
+}
+
+// RegisterHandler creates a new user for the submitted email and returns
+// their bearer token. Clients are expected to store the token and send it
+// back as `Authorization: Bearer <token>` on subsequent requests.
+func (h *AuthHandler) RegisterHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	email := r.FormValue("email")
+	token, err := h.store.RegisterUser(email)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(token))
+}
+
+// LoginHandler verifies an existing bearer token and, for browser/HTMX
+// use, sets it as an HttpOnly session cookie so the client doesn't need to
+// manage the Authorization header itself.
+func (h *AuthHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := r.FormValue("token")
+	if _, err := h.store.LookupUserByToken(token); err != nil {
+		http.Error(w, "Invalid credentials", http.StatusUnauthorized)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Expires:  time.Now().Add(30 * 24 * time.Hour),
+	})
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func setupAuthTestHandler(t *testing.T) (*AuthHandler, func()) {
+	handler, cleanup := setupTestHandler(t)
+	return NewAuthHandler(handler.store), cleanup
+}
+
+func TestRegisterHandler(t *testing.T) {
+	handler, cleanup := setupAuthTestHandler(t)
+	defer cleanup()
+
+	t.Run("registers a new user and returns a token", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("email", "new-user@example.com")
+
+		req := httptest.NewRequest("POST", "/register", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		handler.RegisterHandler(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		if w.Body.Len() == 0 {
+			t.Error("Expected a token in the response body")
+		}
+	})
+
+	t.Run("rejects duplicate email", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("email", "dup@example.com")
+
+		req := httptest.NewRequest("POST", "/register", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		handler.RegisterHandler(httptest.NewRecorder(), req)
+
+		req = httptest.NewRequest("POST", "/register", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		handler.RegisterHandler(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("rejects non-POST requests", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/register", nil)
+		w := httptest.NewRecorder()
+
+		handler.RegisterHandler(w, req)
+
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("Expected status %d, got %d", http.StatusMethodNotAllowed, w.Code)
+		}
+	})
+}
+
+func TestLoginHandler(t *testing.T) {
+	handler, cleanup := setupAuthTestHandler(t)
+	defer cleanup()
+
+	registerForm := url.Values{}
+	registerForm.Add("email", "login-user@example.com")
+	registerReq := httptest.NewRequest("POST", "/register", strings.NewReader(registerForm.Encode()))
+	registerReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	registerW := httptest.NewRecorder()
+	handler.RegisterHandler(registerW, registerReq)
+	token := registerW.Body.String()
+
+	t.Run("sets a session cookie for a valid token", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("token", token)
+
+		req := httptest.NewRequest("POST", "/login", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		handler.LoginHandler(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		cookies := w.Result().Cookies()
+		if len(cookies) != 1 || cookies[0].Name != sessionCookieName {
+			t.Errorf("Expected a %q cookie to be set", sessionCookieName)
+		}
+	})
+
+	t.Run("rejects an invalid token", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("token", "not-a-real-token")
+
+		req := httptest.NewRequest("POST", "/login", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		handler.LoginHandler(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
+		}
+	})
+}
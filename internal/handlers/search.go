@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"memo/internal/models"
+)
+
+// SearchHandler serves GET /todos/search?q=...&completed=..., rendering
+// the same todos.html partial HTMX already swaps in, or JSON on the
+// /api/v1 surface (or when the client asks for it via Accept).
+func (h *TodoHandler) SearchHandler(w http.ResponseWriter, r *http.Request) {
+	uid, ok := userID(r)
+	if !ok {
+		if wantsJSON(r) {
+			writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+		} else {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		}
+		return
+	}
+
+	query := r.URL.Query().Get("q")
+
+	opts := models.SearchOptions{OrderBy: "rank"}
+	if completedStr := r.URL.Query().Get("completed"); completedStr != "" {
+		completed, err := strconv.ParseBool(completedStr)
+		if err != nil {
+			if wantsJSON(r) {
+				writeJSONError(w, http.StatusBadRequest, "invalid completed filter")
+			} else {
+				http.Error(w, "Invalid completed filter", http.StatusBadRequest)
+			}
+			return
+		}
+		opts.Completed = &completed
+	}
+
+	todos, err := h.store.SearchTodos(uid, query, opts)
+	if err != nil {
+		if wantsJSON(r) {
+			writeJSONError(w, http.StatusInternalServerError, "error searching todos")
+		} else {
+			http.Error(w, "Error searching todos", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, todos)
+		return
+	}
+
+	data := struct {
+		Todos []models.Todo
+	}{
+		Todos: todos,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "todos.html", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
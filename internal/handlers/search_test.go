@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"memo/internal/auth"
+	"memo/internal/models"
+)
+
+func TestSearchHandler(t *testing.T) {
+	handler, cleanup := setupTestHandler(t)
+	defer cleanup()
+
+	setupReq := httptest.NewRequest("GET", "/", nil)
+	_, userID := asUser(t, handler.store, setupReq, "search-handler@example.com")
+	handler.store.AddTodo(userID, "Buy milk")
+	handler.store.AddTodo(userID, "Walk the dog")
+
+	t.Run("renders matching todos as HTML", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/todos/search?q=milk", nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), userID))
+		w := httptest.NewRecorder()
+
+		handler.SearchHandler(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "Buy milk") {
+			t.Error("Expected the matching todo in the response")
+		}
+		if strings.Contains(w.Body.String(), "Walk the dog") {
+			t.Error("Expected the non-matching todo to be excluded")
+		}
+	})
+
+	t.Run("returns JSON on the API surface", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/todos/search?q=milk", nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), userID))
+		w := httptest.NewRecorder()
+
+		handler.SearchHandler(w, req)
+
+		var todos []models.Todo
+		if err := json.Unmarshal(w.Body.Bytes(), &todos); err != nil {
+			t.Fatalf("Expected valid JSON response: %v", err)
+		}
+		if len(todos) != 1 {
+			t.Errorf("Expected 1 result, got %d", len(todos))
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"memo/internal/events"
+)
+
+// sseHeartbeatInterval keeps idle proxies from closing the connection.
+const sseHeartbeatInterval = 20 * time.Second
+
+// EventsHandler streams add/toggle/delete notifications for the
+// authenticated user as Server-Sent Events, so HTMX clients using
+// hx-ext="sse" can update without polling.
+func (h *TodoHandler) EventsHandler(w http.ResponseWriter, r *http.Request) {
+	uid, ok := userID(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	broker := h.store.Events()
+	ch, unsubscribe := broker.Subscribe(uid)
+	defer unsubscribe()
+
+	if lastIDStr := r.Header.Get("Last-Event-ID"); lastIDStr != "" {
+		if lastID, err := strconv.ParseInt(lastIDStr, 10, 64); err == nil {
+			for _, evt := range broker.Replay(uid, lastID) {
+				writeSSEEvent(w, evt)
+			}
+			flusher.Flush()
+		}
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case evt, open := <-ch:
+			if !open {
+				return
+			}
+			writeSSEEvent(w, evt)
+			flusher.Flush()
+
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func writeSSEEvent(w http.ResponseWriter, evt events.Event) {
+	data, err := json.Marshal(evt.Todo)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", evt.ID, evt.Type, data)
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"memo/internal/models/sqlite"
+	"memo/internal/router"
+)
+
+func TestEventsHandlerStreamsAddNotifications(t *testing.T) {
+	dbFile := "test_sse_todos.db"
+	store, err := sqlite.NewStore(dbFile)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer func() {
+		store.Close()
+		os.Remove(dbFile)
+	}()
+
+	templates := template.Must(template.New("test").Parse(`
+		{{define "index.html"}}{{end}}
+		{{define "todos.html"}}{{end}}
+	`))
+
+	mux := router.SetupRoutes(store, templates)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	token, err := store.RegisterUser("sse@example.com")
+	if err != nil {
+		t.Fatalf("RegisterUser() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/todos/events", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open SSE stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	lines := make(chan string, 16)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	form := url.Values{}
+	form.Add("text", "Streamed todo")
+	addReq, _ := http.NewRequest("POST", server.URL+"/todos/add", strings.NewReader(form.Encode()))
+	addReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	addReq.Header.Set("Accept", "application/json")
+	addReq.Header.Set("Authorization", "Bearer "+token)
+
+	addResp, err := http.DefaultClient.Do(addReq)
+	if err != nil {
+		t.Fatalf("Failed to add todo: %v", err)
+	}
+	addResp.Body.Close()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case line := <-lines:
+			if strings.Contains(line, "Streamed todo") {
+				return
+			}
+		case <-deadline:
+			t.Fatal("Timed out waiting for the add event on the SSE stream")
+		}
+	}
+}
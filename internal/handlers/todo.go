@@ -5,23 +5,39 @@ import (
 	"net/http"
 	"strconv"
 
+	"memo/internal/auth"
 	"memo/internal/models"
+	"memo/internal/models/sqlite"
 )
 
 type TodoHandler struct {
-	store     *models.TodoStore
+	store     *sqlite.Store
 	templates *template.Template
 }
 
-func NewTodoHandler(store *models.TodoStore, templates *template.Template) *TodoHandler {
+func NewTodoHandler(store *sqlite.Store, templates *template.Template) *TodoHandler {
 	return &TodoHandler{
 		store:     store,
 		templates: templates,
 	}
 }
 
+// userID extracts the authenticated user stashed in the request context by
+// the Authenticate middleware. It should always be present for routes that
+// reach these handlers; a missing user indicates a routing mistake rather
+// than a client error, so we surface it as a 401.
+func userID(r *http.Request) (int, bool) {
+	return auth.UserIDFromContext(r.Context())
+}
+
 func (h *TodoHandler) IndexHandler(w http.ResponseWriter, r *http.Request) {
-	todos, err := h.store.GetTodos()
+	uid, ok := userID(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	todos, err := h.store.GetTodos(uid)
 	if err != nil {
 		http.Error(w, "Error retrieving todos", http.StatusInternalServerError)
 		return
@@ -40,12 +56,23 @@ func (h *TodoHandler) IndexHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *TodoHandler) TodosHandler(w http.ResponseWriter, r *http.Request) {
-	todos, err := h.store.GetTodos()
+	uid, ok := userID(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	todos, err := h.store.GetTodos(uid)
 	if err != nil {
 		http.Error(w, "Error retrieving todos", http.StatusInternalServerError)
 		return
 	}
 
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, todos)
+		return
+	}
+
 	data := struct {
 		Todos []models.Todo
 	}{
@@ -64,15 +91,31 @@ func (h *TodoHandler) AddTodoHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	uid, ok := userID(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	text := r.FormValue("text")
 	// Validation is now handled in the store layer
-	_, err := h.store.AddTodo(text)
+	todo, err := h.store.AddTodo(uid, text)
 	if err != nil {
+		if wantsJSON(r) {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	todos, err := h.store.GetTodos()
+	if wantsJSON(r) {
+		w.Header().Set("Location", "/api/v1/todos/"+strconv.Itoa(todo.ID))
+		writeJSON(w, http.StatusCreated, todo)
+		return
+	}
+
+	todos, err := h.store.GetTodos(uid)
 	if err != nil {
 		http.Error(w, "Error retrieving todos", http.StatusInternalServerError)
 		return
@@ -96,6 +139,12 @@ func (h *TodoHandler) ToggleTodoHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	uid, ok := userID(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	idStr := r.URL.Path[len("/todos/toggle/"):]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
@@ -103,8 +152,12 @@ func (h *TodoHandler) ToggleTodoHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.store.ToggleTodo(id)
+	err = h.store.ToggleTodo(uid, id)
 	if err != nil {
+		if wantsJSON(r) {
+			apiStoreError(w, err)
+			return
+		}
 		if err.Error() == "todo not found" {
 			http.Error(w, "Todo not found", http.StatusNotFound)
 		} else {
@@ -113,12 +166,23 @@ func (h *TodoHandler) ToggleTodoHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	todos, err := h.store.GetTodos()
+	todos, err := h.store.GetTodos(uid)
 	if err != nil {
 		http.Error(w, "Error retrieving todos", http.StatusInternalServerError)
 		return
 	}
 
+	if wantsJSON(r) {
+		for _, todo := range todos {
+			if todo.ID == id {
+				writeJSON(w, http.StatusOK, todo)
+				return
+			}
+		}
+		writeJSONError(w, http.StatusNotFound, "todo not found")
+		return
+	}
+
 	data := struct {
 		Todos []models.Todo
 	}{
@@ -137,6 +201,12 @@ func (h *TodoHandler) DeleteTodoHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	uid, ok := userID(r)
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	idStr := r.URL.Path[len("/todos/delete/"):]
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
@@ -144,8 +214,12 @@ func (h *TodoHandler) DeleteTodoHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = h.store.DeleteTodo(id)
+	err = h.store.DeleteTodo(uid, id)
 	if err != nil {
+		if wantsJSON(r) {
+			apiStoreError(w, err)
+			return
+		}
 		if err.Error() == "todo not found" {
 			http.Error(w, "Todo not found", http.StatusNotFound)
 		} else {
@@ -154,7 +228,12 @@ func (h *TodoHandler) DeleteTodoHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	todos, err := h.store.GetTodos()
+	if wantsJSON(r) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	todos, err := h.store.GetTodos(uid)
 	if err != nil {
 		http.Error(w, "Error retrieving todos", http.StatusInternalServerError)
 		return
@@ -170,4 +249,4 @@ func (h *TodoHandler) DeleteTodoHandler(w http.ResponseWriter, r *http.Request)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-}
\ No newline at end of file
+}
@@ -10,13 +10,14 @@ import (
 	"strings"
 	"testing"
 
-	"memo/internal/models"
+	"memo/internal/auth"
+	"memo/internal/models/sqlite"
 )
 
 func setupTestHandler(t *testing.T) (*TodoHandler, func()) {
 	// Create test database
 	dbFile := "test_handler_todos.db"
-	store, err := models.NewTodoStore(dbFile)
+	store, err := sqlite.NewStore(dbFile)
 	if err != nil {
 		t.Fatalf("Failed to create test database: %v", err)
 	}
@@ -52,12 +53,30 @@ func setupTestHandler(t *testing.T) (*TodoHandler, func()) {
 	return handler, cleanup
 }
 
+// asUser registers a throwaway user and returns an otherwise-identical
+// request carrying their ID, as the Authenticate middleware would.
+func asUser(t *testing.T, store *sqlite.Store, req *http.Request, email string) (*http.Request, int) {
+	t.Helper()
+
+	token, err := store.RegisterUser(email)
+	if err != nil {
+		t.Fatalf("RegisterUser() error = %v", err)
+	}
+	userID, err := store.LookupUserByToken(token)
+	if err != nil {
+		t.Fatalf("LookupUserByToken() error = %v", err)
+	}
+
+	return req.WithContext(auth.WithUserID(req.Context(), userID)), userID
+}
+
 func TestIndexHandler(t *testing.T) {
 	handler, cleanup := setupTestHandler(t)
 	defer cleanup()
 
 	t.Run("renders index page with no todos", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/", nil)
+		req, _ = asUser(t, handler.store, req, "index-1@example.com")
 		w := httptest.NewRecorder()
 
 		handler.IndexHandler(w, req)
@@ -73,10 +92,11 @@ func TestIndexHandler(t *testing.T) {
 	})
 
 	t.Run("renders index page with todos", func(t *testing.T) {
-		// Add a test todo
-		handler.store.AddTodo("Test todo")
-
 		req := httptest.NewRequest("GET", "/", nil)
+		req, userID := asUser(t, handler.store, req, "index-2@example.com")
+
+		handler.store.AddTodo(userID, "Test todo")
+
 		w := httptest.NewRecorder()
 
 		handler.IndexHandler(w, req)
@@ -90,6 +110,17 @@ func TestIndexHandler(t *testing.T) {
 			t.Error("Expected todo text in response")
 		}
 	})
+
+	t.Run("rejects unauthenticated requests", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		w := httptest.NewRecorder()
+
+		handler.IndexHandler(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
+		}
+	})
 }
 
 func TestTodosHandler(t *testing.T) {
@@ -97,9 +128,11 @@ func TestTodosHandler(t *testing.T) {
 	defer cleanup()
 
 	t.Run("renders todos partial", func(t *testing.T) {
-		handler.store.AddTodo("Test todo")
-
 		req := httptest.NewRequest("GET", "/todos", nil)
+		req, userID := asUser(t, handler.store, req, "todos-1@example.com")
+
+		handler.store.AddTodo(userID, "Test todo")
+
 		w := httptest.NewRecorder()
 
 		handler.TodosHandler(w, req)
@@ -125,6 +158,7 @@ func TestAddTodoHandler(t *testing.T) {
 
 		req := httptest.NewRequest("POST", "/todos/add", strings.NewReader(form.Encode()))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req, userID := asUser(t, handler.store, req, "add-1@example.com")
 		w := httptest.NewRecorder()
 
 		handler.AddTodoHandler(w, req)
@@ -134,7 +168,7 @@ func TestAddTodoHandler(t *testing.T) {
 		}
 
 		// Verify todo was added
-		todos, _ := handler.store.GetTodos()
+		todos, _ := handler.store.GetTodos(userID)
 		if len(todos) != 1 {
 			t.Errorf("Expected 1 todo, got %d", len(todos))
 		}
@@ -149,6 +183,7 @@ func TestAddTodoHandler(t *testing.T) {
 
 		req := httptest.NewRequest("POST", "/todos/add", strings.NewReader(form.Encode()))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req, _ = asUser(t, handler.store, req, "add-2@example.com")
 		w := httptest.NewRecorder()
 
 		handler.AddTodoHandler(w, req)
@@ -160,6 +195,7 @@ func TestAddTodoHandler(t *testing.T) {
 
 	t.Run("rejects non-POST requests", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/todos/add", nil)
+		req, _ = asUser(t, handler.store, req, "add-3@example.com")
 		w := httptest.NewRecorder()
 
 		handler.AddTodoHandler(w, req)
@@ -175,6 +211,7 @@ func TestAddTodoHandler(t *testing.T) {
 
 		req := httptest.NewRequest("POST", "/todos/add", strings.NewReader(form.Encode()))
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req, _ = asUser(t, handler.store, req, "add-4@example.com")
 		w := httptest.NewRecorder()
 
 		handler.AddTodoHandler(w, req)
@@ -183,6 +220,21 @@ func TestAddTodoHandler(t *testing.T) {
 			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
 		}
 	})
+
+	t.Run("rejects unauthenticated requests", func(t *testing.T) {
+		form := url.Values{}
+		form.Add("text", "New todo")
+
+		req := httptest.NewRequest("POST", "/todos/add", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+
+		handler.AddTodoHandler(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
+		}
+	})
 }
 
 func TestToggleTodoHandler(t *testing.T) {
@@ -190,9 +242,12 @@ func TestToggleTodoHandler(t *testing.T) {
 	defer cleanup()
 
 	t.Run("toggles existing todo", func(t *testing.T) {
-		todo, _ := handler.store.AddTodo("Test todo")
+		setupReq := httptest.NewRequest("GET", "/", nil)
+		_, userID := asUser(t, handler.store, setupReq, "toggle-1@example.com")
+		todo, _ := handler.store.AddTodo(userID, "Test todo")
 
 		req := httptest.NewRequest("PUT", "/todos/toggle/"+strconv.Itoa(todo.ID), nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), userID))
 		w := httptest.NewRecorder()
 
 		handler.ToggleTodoHandler(w, req)
@@ -202,7 +257,7 @@ func TestToggleTodoHandler(t *testing.T) {
 		}
 
 		// Verify todo was toggled
-		todos, _ := handler.store.GetTodos()
+		todos, _ := handler.store.GetTodos(userID)
 		if len(todos) != 1 {
 			t.Fatal("Expected 1 todo")
 		}
@@ -213,6 +268,23 @@ func TestToggleTodoHandler(t *testing.T) {
 
 	t.Run("returns 404 for non-existent todo", func(t *testing.T) {
 		req := httptest.NewRequest("PUT", "/todos/toggle/99999", nil)
+		req, _ = asUser(t, handler.store, req, "toggle-2@example.com")
+		w := httptest.NewRecorder()
+
+		handler.ToggleTodoHandler(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+
+	t.Run("returns 404 for another user's todo", func(t *testing.T) {
+		ownerReq := httptest.NewRequest("PUT", "/todos/toggle/", nil)
+		ownerReq, ownerID := asUser(t, handler.store, ownerReq, "toggle-owner@example.com")
+		todo, _ := handler.store.AddTodo(ownerID, "Owner's todo")
+
+		req := httptest.NewRequest("PUT", "/todos/toggle/"+strconv.Itoa(todo.ID), nil)
+		req, _ = asUser(t, handler.store, req, "toggle-intruder@example.com")
 		w := httptest.NewRecorder()
 
 		handler.ToggleTodoHandler(w, req)
@@ -224,6 +296,7 @@ func TestToggleTodoHandler(t *testing.T) {
 
 	t.Run("returns 400 for invalid ID", func(t *testing.T) {
 		req := httptest.NewRequest("PUT", "/todos/toggle/invalid", nil)
+		req, _ = asUser(t, handler.store, req, "toggle-3@example.com")
 		w := httptest.NewRecorder()
 
 		handler.ToggleTodoHandler(w, req)
@@ -235,6 +308,7 @@ func TestToggleTodoHandler(t *testing.T) {
 
 	t.Run("rejects non-PUT requests", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/todos/toggle/1", nil)
+		req, _ = asUser(t, handler.store, req, "toggle-4@example.com")
 		w := httptest.NewRecorder()
 
 		handler.ToggleTodoHandler(w, req)
@@ -250,9 +324,12 @@ func TestDeleteTodoHandler(t *testing.T) {
 	defer cleanup()
 
 	t.Run("deletes existing todo", func(t *testing.T) {
-		todo, _ := handler.store.AddTodo("Test todo")
+		setupReq := httptest.NewRequest("GET", "/", nil)
+		_, userID := asUser(t, handler.store, setupReq, "delete-1@example.com")
+		todo, _ := handler.store.AddTodo(userID, "Test todo")
 
 		req := httptest.NewRequest("DELETE", "/todos/delete/"+strconv.Itoa(todo.ID), nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), userID))
 		w := httptest.NewRecorder()
 
 		handler.DeleteTodoHandler(w, req)
@@ -262,7 +339,7 @@ func TestDeleteTodoHandler(t *testing.T) {
 		}
 
 		// Verify todo was deleted
-		todos, _ := handler.store.GetTodos()
+		todos, _ := handler.store.GetTodos(userID)
 		if len(todos) != 0 {
 			t.Errorf("Expected 0 todos, got %d", len(todos))
 		}
@@ -270,6 +347,7 @@ func TestDeleteTodoHandler(t *testing.T) {
 
 	t.Run("returns 404 for non-existent todo", func(t *testing.T) {
 		req := httptest.NewRequest("DELETE", "/todos/delete/99999", nil)
+		req, _ = asUser(t, handler.store, req, "delete-2@example.com")
 		w := httptest.NewRecorder()
 
 		handler.DeleteTodoHandler(w, req)
@@ -279,8 +357,30 @@ func TestDeleteTodoHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("returns 404 for another user's todo", func(t *testing.T) {
+		ownerReq := httptest.NewRequest("GET", "/", nil)
+		ownerReq, ownerID := asUser(t, handler.store, ownerReq, "delete-owner@example.com")
+		todo, _ := handler.store.AddTodo(ownerID, "Owner's todo")
+
+		req := httptest.NewRequest("DELETE", "/todos/delete/"+strconv.Itoa(todo.ID), nil)
+		req, _ = asUser(t, handler.store, req, "delete-intruder@example.com")
+		w := httptest.NewRecorder()
+
+		handler.DeleteTodoHandler(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+
+		todos, _ := handler.store.GetTodos(ownerID)
+		if len(todos) != 1 {
+			t.Errorf("Expected owner's todo to remain, got %d todos", len(todos))
+		}
+	})
+
 	t.Run("returns 400 for invalid ID", func(t *testing.T) {
 		req := httptest.NewRequest("DELETE", "/todos/delete/invalid", nil)
+		req, _ = asUser(t, handler.store, req, "delete-3@example.com")
 		w := httptest.NewRecorder()
 
 		handler.DeleteTodoHandler(w, req)
@@ -292,6 +392,7 @@ func TestDeleteTodoHandler(t *testing.T) {
 
 	t.Run("rejects non-DELETE requests", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/todos/delete/1", nil)
+		req, _ = asUser(t, handler.store, req, "delete-4@example.com")
 		w := httptest.NewRecorder()
 
 		handler.DeleteTodoHandler(w, req)
@@ -300,4 +401,4 @@ func TestDeleteTodoHandler(t *testing.T) {
 			t.Errorf("Expected status %d, got %d", http.StatusMethodNotAllowed, w.Code)
 		}
 	})
-}
\ No newline at end of file
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// RequestLogger emits one structured log line per request via log/slog,
+// recording the method, path, status, duration, response size, and
+// remote address.
+func RequestLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(rec, r)
+
+		attrs := []any{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"duration", time.Since(start),
+			"bytes", rec.bytesWritten,
+			"remote", r.RemoteAddr,
+		}
+
+		if rec.status >= 400 {
+			slog.Warn("request", attrs...)
+		} else {
+			slog.Info("request", attrs...)
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// requestKey identifies one (path, method, code) combination for
+// counting requests and summing latency.
+type requestKey struct {
+	path   string
+	method string
+	code   int
+}
+
+// durationBuckets are the histogram bucket boundaries, in seconds, used
+// for http_request_duration_seconds. They follow the same "human-typed
+// sub-second latency" spread as the Prometheus client library defaults.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// idRoutePrefixes are the path prefixes that take a numeric ID suffix
+// (see router.SetupRoutes). normalizePath collapses everything under
+// them to a single label value so metrics cardinality tracks the number
+// of routes, not the number of todos ever requested.
+var idRoutePrefixes = []string{
+	"/todos/toggle/",
+	"/todos/delete/",
+	"/api/v1/todos/",
+}
+
+// normalizePath maps a request path to its route template for labeling,
+// so "/todos/toggle/7" and "/todos/toggle/812" share one time series
+// instead of each minting their own.
+func normalizePath(path string) string {
+	if path == "/api/v1/todos/search" {
+		return path
+	}
+	for _, prefix := range idRoutePrefixes {
+		if strings.HasPrefix(path, prefix) && len(path) > len(prefix) {
+			return prefix + ":id"
+		}
+	}
+	return path
+}
+
+// Metrics tracks request counts and latency histograms and exposes them
+// in Prometheus text exposition format. There's no Prometheus client
+// dependency available here, so counters are kept by hand; this is
+// functionally the same zero-dep approach expvar would give us.
+type Metrics struct {
+	mu             sync.Mutex
+	requestTotals  map[requestKey]int64
+	durationSums   map[requestKey]float64
+	durationCounts map[requestKey]int64
+	bucketCounts   map[requestKey][]int64 // parallel to durationBuckets, cumulative counts (observations <= bucket)
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		requestTotals:  make(map[requestKey]int64),
+		durationSums:   make(map[requestKey]float64),
+		durationCounts: make(map[requestKey]int64),
+		bucketCounts:   make(map[requestKey][]int64),
+	}
+}
+
+// Middleware records one request's outcome and wraps next.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(rec, r)
+
+		elapsed := time.Since(start).Seconds()
+		key := requestKey{path: normalizePath(r.URL.Path), method: r.Method, code: rec.status}
+
+		m.mu.Lock()
+		m.requestTotals[key]++
+		m.durationSums[key] += elapsed
+		m.durationCounts[key]++
+
+		buckets := m.bucketCounts[key]
+		if buckets == nil {
+			buckets = make([]int64, len(durationBuckets))
+			m.bucketCounts[key] = buckets
+		}
+		for i, le := range durationBuckets {
+			if elapsed <= le {
+				buckets[i]++
+			}
+		}
+		m.mu.Unlock()
+	})
+}
+
+// Handler serves the accumulated counters in Prometheus text format on
+// /metrics.
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+		var keys []requestKey
+		for k := range m.requestTotals {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].path != keys[j].path {
+				return keys[i].path < keys[j].path
+			}
+			if keys[i].method != keys[j].method {
+				return keys[i].method < keys[j].method
+			}
+			return keys[i].code < keys[j].code
+		})
+
+		fmt.Fprintln(w, "# HELP http_requests_total Total number of HTTP requests.")
+		fmt.Fprintln(w, "# TYPE http_requests_total counter")
+		for _, k := range keys {
+			fmt.Fprintf(w, "http_requests_total{path=%q,method=%q,code=%q} %d\n",
+				k.path, k.method, strconv.Itoa(k.code), m.requestTotals[k])
+		}
+
+		fmt.Fprintln(w, "# HELP http_request_duration_seconds Latency distribution of HTTP requests in seconds.")
+		fmt.Fprintln(w, "# TYPE http_request_duration_seconds histogram")
+		for _, k := range keys {
+			buckets := m.bucketCounts[k]
+			for i, le := range durationBuckets {
+				fmt.Fprintf(w, "http_request_duration_seconds_bucket{path=%q,method=%q,code=%q,le=%q} %d\n",
+					k.path, k.method, strconv.Itoa(k.code), strconv.FormatFloat(le, 'f', -1, 64), buckets[i])
+			}
+			fmt.Fprintf(w, "http_request_duration_seconds_bucket{path=%q,method=%q,code=%q,le=\"+Inf\"} %d\n",
+				k.path, k.method, strconv.Itoa(k.code), m.durationCounts[k])
+			fmt.Fprintf(w, "http_request_duration_seconds_sum{path=%q,method=%q,code=%q} %s\n",
+				k.path, k.method, strconv.Itoa(k.code), strconv.FormatFloat(m.durationSums[k], 'f', 6, 64))
+			fmt.Fprintf(w, "http_request_duration_seconds_count{path=%q,method=%q,code=%q} %d\n",
+				k.path, k.method, strconv.Itoa(k.code), m.durationCounts[k])
+		}
+	})
+}
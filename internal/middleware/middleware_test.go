@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func helloHandler(status int, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	})
+}
+
+func TestGzip(t *testing.T) {
+	t.Run("compresses when Accept-Encoding allows it", func(t *testing.T) {
+		handler := Gzip(helloHandler(http.StatusOK, strings.Repeat("hello", 100)))
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		if w.Header().Get("Content-Encoding") != "gzip" {
+			t.Fatalf("Expected Content-Encoding: gzip, got %q", w.Header().Get("Content-Encoding"))
+		}
+
+		gr, err := gzip.NewReader(w.Body)
+		if err != nil {
+			t.Fatalf("Expected a valid gzip stream: %v", err)
+		}
+		decoded, err := io.ReadAll(gr)
+		if err != nil {
+			t.Fatalf("Failed to read gzip stream: %v", err)
+		}
+		if string(decoded) != strings.Repeat("hello", 100) {
+			t.Errorf("Unexpected decoded body: %q", decoded)
+		}
+	})
+
+	t.Run("passes through when not requested", func(t *testing.T) {
+		handler := Gzip(helloHandler(http.StatusOK, "hello"))
+
+		req := httptest.NewRequest("GET", "/", nil)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		if w.Header().Get("Content-Encoding") == "gzip" {
+			t.Error("Expected no Content-Encoding header without Accept-Encoding: gzip")
+		}
+		if w.Body.String() != "hello" {
+			t.Errorf("Expected uncompressed body, got %q", w.Body.String())
+		}
+	})
+}
+
+func TestRequestLogger(t *testing.T) {
+	t.Run("records non-2xx responses", func(t *testing.T) {
+		var buf strings.Builder
+		prev := slog.Default()
+		slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+		defer slog.SetDefault(prev)
+
+		handler := RequestLogger(helloHandler(http.StatusNotFound, "missing"))
+
+		req := httptest.NewRequest("GET", "/missing", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if !strings.Contains(buf.String(), "status=404") {
+			t.Errorf("Expected log line to record status=404, got %q", buf.String())
+		}
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	t.Run("exposes http_requests_total on /metrics", func(t *testing.T) {
+		m := NewMetrics()
+		handler := m.Middleware(helloHandler(http.StatusOK, "ok"))
+
+		req := httptest.NewRequest("GET", "/todos", nil)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		metricsReq := httptest.NewRequest("GET", "/metrics", nil)
+		metricsW := httptest.NewRecorder()
+		m.Handler().ServeHTTP(metricsW, metricsReq)
+
+		body := metricsW.Body.String()
+		if !strings.Contains(body, `http_requests_total{path="/todos",method="GET",code="200"} 1`) {
+			t.Errorf("Expected metrics body to contain the recorded request, got %q", body)
+		}
+	})
+
+	t.Run("exposes a latency histogram with buckets, not just a sum", func(t *testing.T) {
+		m := NewMetrics()
+		handler := m.Middleware(helloHandler(http.StatusOK, "ok"))
+
+		req := httptest.NewRequest("GET", "/todos", nil)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		metricsReq := httptest.NewRequest("GET", "/metrics", nil)
+		metricsW := httptest.NewRecorder()
+		m.Handler().ServeHTTP(metricsW, metricsReq)
+
+		body := metricsW.Body.String()
+		if !strings.Contains(body, `http_request_duration_seconds_bucket{path="/todos",method="GET",code="200",le="+Inf"} 1`) {
+			t.Errorf("Expected an observation in the +Inf bucket, got %q", body)
+		}
+		if !strings.Contains(body, `http_request_duration_seconds_count{path="/todos",method="GET",code="200"} 1`) {
+			t.Errorf("Expected a _count series, got %q", body)
+		}
+	})
+
+	t.Run("normalizes ID path segments to avoid unbounded cardinality", func(t *testing.T) {
+		m := NewMetrics()
+		handler := m.Middleware(helloHandler(http.StatusOK, "ok"))
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/todos/toggle/7", nil))
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/todos/toggle/812", nil))
+
+		metricsReq := httptest.NewRequest("GET", "/metrics", nil)
+		metricsW := httptest.NewRecorder()
+		m.Handler().ServeHTTP(metricsW, metricsReq)
+
+		body := metricsW.Body.String()
+		if !strings.Contains(body, `http_requests_total{path="/todos/toggle/:id",method="POST",code="200"} 2`) {
+			t.Errorf("Expected both toggle requests to share one normalized series, got %q", body)
+		}
+		if strings.Contains(body, `path="/todos/toggle/7"`) || strings.Contains(body, `path="/todos/toggle/812"`) {
+			t.Errorf("Expected raw todo IDs not to leak into path labels, got %q", body)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+// Package migrations applies the embedded, numbered SQL files under
+// migrations/ to a SQLite database, tracking what's been applied in a
+// schema_migrations table so startup can run Migrate unconditionally.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// loadMigrations reads and parses the embedded *.sql files, sorted by
+// their numeric prefix (e.g. "0002_add_user_id.sql" -> version 2).
+func loadMigrations() ([]migration, error) {
+	entries, err := migrationFS.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]migration, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		base := strings.TrimSuffix(entry.Name(), ".sql")
+		parts := strings.SplitN(base, "_", 2)
+		version, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("migration file %q does not start with a numeric version: %w", entry.Name(), err)
+		}
+
+		content, err := migrationFS.ReadFile("migrations/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		name := base
+		if len(parts) == 2 {
+			name = parts[1]
+		}
+
+		migrations = append(migrations, migration{version: version, name: name, sql: string(content)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+
+	return migrations, nil
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]bool, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+// Migrate applies every pending migration to db, forward-only. It's safe
+// to call on every startup: migrations already recorded in
+// schema_migrations are skipped. A BEGIN IMMEDIATE acquired up front acts
+// as an advisory lock so two processes starting at once don't race to
+// apply the same migration twice.
+func Migrate(db *sql.DB) error {
+	ctx := context.Background()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Without this, a concurrent BEGIN IMMEDIATE below fails immediately
+	// with "database is locked" instead of waiting for the winner to
+	// finish, defeating the point of the lock.
+	if _, err := conn.ExecContext(ctx, "PRAGMA busy_timeout = 5000"); err != nil {
+		return fmt.Errorf("setting busy_timeout: %w", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			applied_at DATETIME NOT NULL
+		)`); err != nil {
+		return fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	all, err := loadMigrations()
+	if err != nil {
+		return fmt.Errorf("loading migrations: %w", err)
+	}
+
+	// BEGIN IMMEDIATE must be acquired before computing which migrations
+	// are pending, not after: otherwise two processes starting at once
+	// can both read the same empty schema_migrations and both decide the
+	// same migrations are pending, and whichever loses the race to
+	// BEGIN IMMEDIATE re-applies them on top of the winner's work. The
+	// pending set is recomputed here, under the lock, so a loser sees
+	// the winner's already-applied versions and has nothing left to do.
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return fmt.Errorf("acquiring migration lock: %w", err)
+	}
+
+	applied, err := appliedVersions(ctx, conn)
+	if err != nil {
+		conn.ExecContext(ctx, "ROLLBACK")
+		return fmt.Errorf("reading applied migrations: %w", err)
+	}
+
+	var pending []migration
+	for _, m := range all {
+		if !applied[m.version] {
+			pending = append(pending, m)
+		}
+	}
+	if len(pending) == 0 {
+		conn.ExecContext(ctx, "ROLLBACK")
+		return nil
+	}
+
+	for _, m := range pending {
+		if _, err := conn.ExecContext(ctx, m.sql); err != nil {
+			conn.ExecContext(ctx, "ROLLBACK")
+			return fmt.Errorf("applying migration %04d_%s: %w", m.version, m.name, err)
+		}
+
+		if _, err := conn.ExecContext(ctx,
+			`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`,
+			m.version, time.Now()); err != nil {
+			conn.ExecContext(ctx, "ROLLBACK")
+			return fmt.Errorf("recording migration %04d_%s: %w", m.version, m.name, err)
+		}
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return fmt.Errorf("committing migrations: %w", err)
+	}
+
+	return nil
+}
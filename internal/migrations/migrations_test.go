@@ -0,0 +1,103 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var testDBCounter int64
+
+func openTestDB(t *testing.T) (*sql.DB, func()) {
+	t.Helper()
+
+	dbFile := fmt.Sprintf("test_migrations_%d.db", atomic.AddInt64(&testDBCounter, 1))
+	db, err := sql.Open("sqlite3", dbFile)
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+
+	cleanup := func() {
+		db.Close()
+		os.Remove(dbFile)
+	}
+
+	return db, cleanup
+}
+
+func TestMigrate(t *testing.T) {
+	db, cleanup := openTestDB(t)
+	defer cleanup()
+
+	t.Run("applies all migrations to an empty database", func(t *testing.T) {
+		if err := Migrate(db); err != nil {
+			t.Fatalf("Migrate() error = %v", err)
+		}
+
+		var count int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&count); err != nil {
+			t.Fatalf("Failed to read schema_migrations: %v", err)
+		}
+
+		all, err := loadMigrations()
+		if err != nil {
+			t.Fatalf("loadMigrations() error = %v", err)
+		}
+		if count != len(all) {
+			t.Errorf("Expected %d applied migrations, got %d", len(all), count)
+		}
+
+		if _, err := db.Exec(`INSERT INTO users (email, token) VALUES ('a@example.com', 'tok')`); err != nil {
+			t.Errorf("Expected users table to exist: %v", err)
+		}
+		if _, err := db.Exec(`INSERT INTO todos (user_id, text, priority) VALUES (1, 'test', 2)`); err != nil {
+			t.Errorf("Expected todos table to have a priority column: %v", err)
+		}
+	})
+
+	t.Run("running Migrate twice is idempotent", func(t *testing.T) {
+		if err := Migrate(db); err != nil {
+			t.Fatalf("Second Migrate() call error = %v", err)
+		}
+
+		var count int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&count); err != nil {
+			t.Fatalf("Failed to read schema_migrations: %v", err)
+		}
+
+		all, _ := loadMigrations()
+		if count != len(all) {
+			t.Errorf("Expected migration count to stay at %d after a second run, got %d", len(all), count)
+		}
+	})
+
+	t.Run("concurrent startups don't double-apply migrations", func(t *testing.T) {
+		concurrentDB, concurrentCleanup := openTestDB(t)
+		defer concurrentCleanup()
+
+		const numGoroutines = 5
+		results := make(chan error, numGoroutines)
+		for i := 0; i < numGoroutines; i++ {
+			go func() { results <- Migrate(concurrentDB) }()
+		}
+
+		for i := 0; i < numGoroutines; i++ {
+			if err := <-results; err != nil {
+				t.Errorf("Concurrent Migrate() error = %v", err)
+			}
+		}
+
+		var count int
+		if err := concurrentDB.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&count); err != nil {
+			t.Fatalf("Failed to read schema_migrations: %v", err)
+		}
+		all, _ := loadMigrations()
+		if count != len(all) {
+			t.Errorf("Expected %d applied migrations after concurrent startups, got %d", len(all), count)
+		}
+	})
+}
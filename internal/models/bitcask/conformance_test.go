@@ -0,0 +1,28 @@
+package bitcask
+
+import (
+	"os"
+	"testing"
+
+	"memo/internal/models"
+	"memo/internal/models/storetest"
+)
+
+func TestStoreConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) (models.Store, func()) {
+		dir, err := os.MkdirTemp("", "bitcask-conformance-*")
+		if err != nil {
+			t.Fatalf("Failed to create temp dir: %v", err)
+		}
+
+		store, err := NewStore(dir)
+		if err != nil {
+			t.Fatalf("NewStore() error = %v", err)
+		}
+
+		return store, func() {
+			store.Close()
+			os.RemoveAll(dir)
+		}
+	})
+}
@@ -0,0 +1,234 @@
+// Package bitcask is a models.Store backed by prologic/bitcask, a
+// lock-free append-only log-structured KV engine. It trades sqlite's
+// query flexibility for simpler operations: no CGO, no separate server,
+// just a directory of log segments.
+package bitcask
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mills.io/bitcask/v2"
+
+	"memo/internal/models"
+	"memo/internal/models/search"
+)
+
+// record is the JSON envelope stored under each todo's key, wrapping the
+// todo itself with the owning user ID since bitcask only gives us a flat
+// key/value namespace.
+type record struct {
+	UserID int         `json:"user_id"`
+	Todo   models.Todo `json:"todo"`
+}
+
+type Store struct {
+	mu     sync.Mutex // serializes nextID allocation; bitcask itself is safe for concurrent use
+	db     bitcask.DB
+	nextID int
+	index  *search.Index
+}
+
+var _ models.Store = (*Store)(nil)
+
+func NewStore(path string) (*Store, error) {
+	db, err := bitcask.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening bitcask db at %s: %w", path, err)
+	}
+
+	s := &Store{db: db, index: search.NewIndex()}
+	if err := s.rebuildFromDisk(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// rebuildFromDisk scans every existing key once at startup to resume ID
+// allocation and rebuild the search index, since neither survives a
+// restart: bitcask has no auto-increment primitive, and the index
+// (unlike the sqlite FTS5 index) only lives in memory.
+func (s *Store) rebuildFromDisk() error {
+	return s.db.Scan([]byte(keyPrefix), func(key []byte) error {
+		id, err := idFromKey(string(key))
+		if err != nil {
+			return nil // skip keys we don't recognize
+		}
+		if id > s.nextID {
+			s.nextID = id
+		}
+
+		data, err := s.db.Get(key)
+		if err != nil {
+			return err
+		}
+		var rec record
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil
+		}
+		s.index.Add(id, rec.Todo.Text)
+
+		return nil
+	})
+}
+
+const keyPrefix = "todo:"
+
+func todoKey(id int) string {
+	return keyPrefix + strconv.Itoa(id)
+}
+
+func idFromKey(key string) (int, error) {
+	return strconv.Atoi(strings.TrimPrefix(key, keyPrefix))
+}
+
+func validateTodoText(text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return errors.New("todo text cannot be empty")
+	}
+	if len(text) > 500 {
+		return errors.New("todo text cannot exceed 500 characters")
+	}
+	return nil
+}
+
+func (s *Store) AddTodo(userID int, text string) (models.Todo, error) {
+	if err := validateTodoText(text); err != nil {
+		return models.Todo{}, err
+	}
+	text = strings.TrimSpace(text)
+
+	s.mu.Lock()
+	s.nextID++
+	id := s.nextID
+	s.mu.Unlock()
+
+	todo := models.Todo{
+		ID:        id,
+		Text:      text,
+		Completed: false,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.put(userID, todo); err != nil {
+		return models.Todo{}, err
+	}
+
+	return todo, nil
+}
+
+func (s *Store) put(userID int, todo models.Todo) error {
+	data, err := json.Marshal(record{UserID: userID, Todo: todo})
+	if err != nil {
+		return err
+	}
+	if err := s.db.Put([]byte(todoKey(todo.ID)), data); err != nil {
+		return err
+	}
+	s.index.Add(todo.ID, todo.Text)
+	return nil
+}
+
+// GetTodos scans every todo key and filters by owner, since bitcask has
+// no secondary index on user ID. Scan yields keys in lexicographic
+// order, not numeric order, so newest-first is produced by sorting on
+// ID rather than reversing scan order, matching the sqlite backend.
+func (s *Store) GetTodos(userID int) ([]models.Todo, error) {
+	var todos []models.Todo
+	err := s.db.Scan([]byte(keyPrefix), func(key []byte) error {
+		data, err := s.db.Get(key)
+		if err != nil {
+			return err
+		}
+		var rec record
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil
+		}
+		if rec.UserID == userID {
+			todos = append(todos, rec.Todo)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning todos: %w", err)
+	}
+
+	sort.Slice(todos, func(i, j int) bool { return todos[i].ID > todos[j].ID })
+
+	return todos, nil
+}
+
+func (s *Store) getOwned(userID, id int) (record, error) {
+	data, err := s.db.Get([]byte(todoKey(id)))
+	if err != nil {
+		return record{}, errors.New("todo not found")
+	}
+	var rec record
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return record{}, err
+	}
+	if rec.UserID != userID {
+		return record{}, errors.New("todo not found")
+	}
+	return rec, nil
+}
+
+func (s *Store) ToggleTodo(userID, id int) error {
+	if id <= 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	rec, err := s.getOwned(userID, id)
+	if err != nil {
+		return err
+	}
+	rec.Todo.Completed = !rec.Todo.Completed
+
+	return s.put(userID, rec.Todo)
+}
+
+func (s *Store) DeleteTodo(userID, id int) error {
+	if id <= 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	if _, err := s.getOwned(userID, id); err != nil {
+		return err
+	}
+
+	if err := s.db.Delete([]byte(todoKey(id))); err != nil {
+		return err
+	}
+	s.index.Remove(id)
+	return nil
+}
+
+// SearchTodos returns userID's todos matching query, ranked by TF-IDF
+// relevance via the in-memory inverted index (see models/search). Unlike
+// sqlite's FTS5 index, this one isn't persisted to disk; rebuildFromDisk
+// reconstructs it from the bitcask log on startup.
+func (s *Store) SearchTodos(userID int, query string) ([]models.Todo, error) {
+	var todos []models.Todo
+	for _, result := range s.index.Search(query) {
+		rec, err := s.getOwned(userID, result.ID)
+		if err != nil {
+			continue // owned by someone else, or deleted since the index snapshot
+		}
+		todos = append(todos, rec.Todo)
+	}
+
+	return todos, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
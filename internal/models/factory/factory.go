@@ -0,0 +1,37 @@
+// Package factory dispatches on the DB_DRIVER env var to construct the
+// requested models.Store backend. It lives outside package models itself
+// since every backend sub-package imports models, and models importing
+// them back here would be a cycle.
+package factory
+
+import (
+	"fmt"
+
+	"memo/internal/models"
+	"memo/internal/models/bitcask"
+	"memo/internal/models/memory"
+	"memo/internal/models/pogreb"
+	"memo/internal/models/postgres"
+	"memo/internal/models/sqlite"
+)
+
+// Open constructs the backend named by driver, configured via path: a
+// file path for sqlite/bitcask/pogreb, a DSN for postgres, ignored for
+// memory. driver defaults to "sqlite" when empty, matching DB_PATH's
+// existing default-empty-means-"todos.db" convention.
+func Open(driver, path string) (models.Store, error) {
+	switch driver {
+	case "", "sqlite":
+		return sqlite.NewStore(path)
+	case "memory":
+		return memory.NewStore(), nil
+	case "bitcask":
+		return bitcask.NewStore(path)
+	case "pogreb":
+		return pogreb.NewStore(path)
+	case "postgres":
+		return postgres.NewStore(path)
+	default:
+		return nil, fmt.Errorf("unknown DB_DRIVER %q", driver)
+	}
+}
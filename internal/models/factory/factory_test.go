@@ -0,0 +1,35 @@
+package factory
+
+import (
+	"testing"
+
+	"memo/internal/models/memory"
+)
+
+func TestOpen(t *testing.T) {
+	t.Run("defaults to sqlite", func(t *testing.T) {
+		store, err := Open("", t.TempDir()+"/test.db")
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer store.Close()
+	})
+
+	t.Run("opens the memory backend", func(t *testing.T) {
+		store, err := Open("memory", "")
+		if err != nil {
+			t.Fatalf("Open() error = %v", err)
+		}
+		defer store.Close()
+
+		if _, ok := store.(*memory.Store); !ok {
+			t.Errorf("Expected a *memory.Store, got %T", store)
+		}
+	})
+
+	t.Run("rejects an unknown driver", func(t *testing.T) {
+		if _, err := Open("not-a-real-driver", ""); err == nil {
+			t.Error("Expected an error for an unknown driver")
+		}
+	})
+}
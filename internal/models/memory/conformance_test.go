@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"memo/internal/models"
+	"memo/internal/models/storetest"
+)
+
+func TestStoreConformance(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) (models.Store, func()) {
+		return NewStore(), func() {}
+	})
+}
@@ -0,0 +1,47 @@
+// Basic CRUD behavior is covered by the shared conformance suite in
+// conformance_test.go; this file only tests SearchTodos, which isn't
+// part of the common models.Store contract.
+package memory
+
+import "testing"
+
+func TestSearchTodos(t *testing.T) {
+	s := NewStore()
+	s.AddTodo(1, "Buy milk and eggs")
+	s.AddTodo(1, "Walk the dog")
+
+	t.Run("matches a term", func(t *testing.T) {
+		todos, err := s.SearchTodos(1, "milk")
+		if err != nil {
+			t.Fatalf("SearchTodos() error = %v", err)
+		}
+		if len(todos) != 1 || todos[0].Text != "Buy milk and eggs" {
+			t.Errorf("Expected to find the milk todo, got %+v", todos)
+		}
+	})
+
+	t.Run("does not leak another user's todos", func(t *testing.T) {
+		s.AddTodo(2, "Buy milk for the office")
+
+		todos, err := s.SearchTodos(1, "milk")
+		if err != nil {
+			t.Fatalf("SearchTodos() error = %v", err)
+		}
+		if len(todos) != 1 {
+			t.Errorf("Expected only the calling user's todo, got %d results", len(todos))
+		}
+	})
+
+	t.Run("reflects deletions", func(t *testing.T) {
+		deleted, _ := s.AddTodo(1, "Temporary todo")
+		s.DeleteTodo(1, deleted.ID)
+
+		todos, err := s.SearchTodos(1, "temporary")
+		if err != nil {
+			t.Fatalf("SearchTodos() error = %v", err)
+		}
+		if len(todos) != 0 {
+			t.Errorf("Expected deleted todo to no longer match, got %+v", todos)
+		}
+	})
+}
@@ -0,0 +1,146 @@
+// Package memory is an in-process models.Store backed by a plain map.
+// It doesn't persist anything to disk; it exists for tests and for
+// embedders who want todo storage without a database dependency.
+package memory
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"memo/internal/models"
+	"memo/internal/models/search"
+)
+
+type Store struct {
+	mu     sync.RWMutex
+	todos  map[int]models.Todo
+	owners map[int]int // todo ID -> user ID
+	nextID int
+	index  *search.Index
+}
+
+var _ models.Store = (*Store)(nil)
+
+func NewStore() *Store {
+	return &Store{
+		todos:  make(map[int]models.Todo),
+		owners: make(map[int]int),
+		index:  search.NewIndex(),
+	}
+}
+
+func validateTodoText(text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return errors.New("todo text cannot be empty")
+	}
+	if len(text) > 500 {
+		return errors.New("todo text cannot exceed 500 characters")
+	}
+	return nil
+}
+
+func (s *Store) AddTodo(userID int, text string) (models.Todo, error) {
+	if err := validateTodoText(text); err != nil {
+		return models.Todo{}, err
+	}
+	text = strings.TrimSpace(text)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	todo := models.Todo{
+		ID:        s.nextID,
+		Text:      text,
+		Completed: false,
+		CreatedAt: time.Now(),
+	}
+	s.todos[todo.ID] = todo
+	s.owners[todo.ID] = userID
+	s.index.Add(todo.ID, todo.Text)
+
+	return todo, nil
+}
+
+// GetTodos returns userID's todos newest-first, matching the sqlite
+// backend's ORDER BY created_at DESC.
+func (s *Store) GetTodos(userID int) ([]models.Todo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var todos []models.Todo
+	for id := s.nextID; id >= 1; id-- {
+		todo, ok := s.todos[id]
+		if !ok || s.owners[id] != userID {
+			continue
+		}
+		todos = append(todos, todo)
+	}
+
+	return todos, nil
+}
+
+func (s *Store) ToggleTodo(userID, id int) error {
+	if id <= 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	todo, ok := s.todos[id]
+	if !ok || s.owners[id] != userID {
+		return errors.New("todo not found")
+	}
+
+	todo.Completed = !todo.Completed
+	s.todos[id] = todo
+
+	return nil
+}
+
+func (s *Store) DeleteTodo(userID, id int) error {
+	if id <= 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.todos[id]; !ok || s.owners[id] != userID {
+		return errors.New("todo not found")
+	}
+
+	delete(s.todos, id)
+	delete(s.owners, id)
+	s.index.Remove(id)
+
+	return nil
+}
+
+// SearchTodos returns userID's todos matching query, ranked by
+// TF-IDF relevance via the in-memory inverted index. Unlike the sqlite
+// backend's FTS5 search, this index isn't persisted: it's rebuilt as
+// todos are added back in, which is fine since this backend doesn't
+// persist the todos themselves either.
+func (s *Store) SearchTodos(userID int, query string) ([]models.Todo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var todos []models.Todo
+	for _, result := range s.index.Search(query) {
+		if s.owners[result.ID] != userID {
+			continue
+		}
+		todos = append(todos, s.todos[result.ID])
+	}
+
+	return todos, nil
+}
+
+func (s *Store) Close() error {
+	return nil
+}
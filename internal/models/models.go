@@ -0,0 +1,41 @@
+// Package models holds the types shared by every storage backend
+// (sqlite, memory, bitcask, pogreb, ...) and the Store interface each of
+// them implements. The backends themselves live in their own
+// sub-packages so the HTTP layer can depend on this package alone.
+package models
+
+import "time"
+
+type Todo struct {
+	ID        int       `json:"id"`
+	Text      string    `json:"text"`
+	Completed bool      `json:"completed"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SearchOptions narrows a SearchTodos call beyond the free-text query.
+// Not every Store implements search; backends that do expose it as an
+// additional method beyond the Store interface (see sqlite.Store).
+type SearchOptions struct {
+	Completed *bool
+	Before    time.Time
+	After     time.Time
+	Limit     int
+	Offset    int
+	OrderBy   string // "created_at" (default) or "rank"
+}
+
+// Store is the common contract every storage backend satisfies: basic,
+// per-user todo CRUD. Multi-user auth, SSE events and batching are
+// richer capabilities that only the sqlite backend provides, and search
+// differs per backend (sqlite's takes SearchOptions; memory, bitcask and
+// pogreb take only a query; postgres has none). They're exposed as extra
+// methods on the concrete stores rather than folded into this interface,
+// so that simpler backends aren't forced to implement them to be usable.
+type Store interface {
+	AddTodo(userID int, text string) (Todo, error)
+	GetTodos(userID int) ([]Todo, error)
+	ToggleTodo(userID, id int) error
+	DeleteTodo(userID, id int) error
+	Close() error
+}
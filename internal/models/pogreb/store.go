@@ -0,0 +1,233 @@
+// Package pogreb is a models.Store backed by akrylysov/pogreb, an
+// embedded key-value store optimized for random-read/write workloads
+// without bitcask's append-only log compaction behavior.
+package pogreb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akrylysov/pogreb"
+
+	"memo/internal/models"
+	"memo/internal/models/search"
+)
+
+// record mirrors bitcask.record: a flat KV store has no secondary index
+// on user ID, so we keep the owner alongside the todo in the value.
+type record struct {
+	UserID int         `json:"user_id"`
+	Todo   models.Todo `json:"todo"`
+}
+
+type Store struct {
+	mu     sync.Mutex // serializes nextID allocation; pogreb itself is safe for concurrent use
+	db     *pogreb.DB
+	nextID int
+	index  *search.Index
+}
+
+var _ models.Store = (*Store)(nil)
+
+func NewStore(path string) (*Store, error) {
+	db, err := pogreb.Open(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("opening pogreb db at %s: %w", path, err)
+	}
+
+	s := &Store{db: db, index: search.NewIndex()}
+	if err := s.rebuildFromDisk(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+const keyPrefix = "todo:"
+
+func todoKey(id int) []byte {
+	return []byte(keyPrefix + strconv.Itoa(id))
+}
+
+// rebuildFromDisk walks every existing key once at startup to resume ID
+// allocation and rebuild the search index, since neither survives a
+// restart: pogreb has no auto-increment primitive, and the index
+// (unlike the sqlite FTS5 index) only lives in memory.
+func (s *Store) rebuildFromDisk() error {
+	it := s.db.Items()
+	for {
+		key, value, err := it.Next()
+		if errors.Is(err, pogreb.ErrIterationDone) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("iterating todos: %w", err)
+		}
+
+		id, err := strconv.Atoi(strings.TrimPrefix(string(key), keyPrefix))
+		if err != nil {
+			continue
+		}
+		if id > s.nextID {
+			s.nextID = id
+		}
+
+		var rec record
+		if err := json.Unmarshal(value, &rec); err != nil {
+			continue
+		}
+		s.index.Add(id, rec.Todo.Text)
+	}
+}
+
+func validateTodoText(text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return errors.New("todo text cannot be empty")
+	}
+	if len(text) > 500 {
+		return errors.New("todo text cannot exceed 500 characters")
+	}
+	return nil
+}
+
+func (s *Store) AddTodo(userID int, text string) (models.Todo, error) {
+	if err := validateTodoText(text); err != nil {
+		return models.Todo{}, err
+	}
+	text = strings.TrimSpace(text)
+
+	s.mu.Lock()
+	s.nextID++
+	id := s.nextID
+	s.mu.Unlock()
+
+	todo := models.Todo{
+		ID:        id,
+		Text:      text,
+		Completed: false,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.put(userID, todo); err != nil {
+		return models.Todo{}, err
+	}
+
+	return todo, nil
+}
+
+func (s *Store) put(userID int, todo models.Todo) error {
+	data, err := json.Marshal(record{UserID: userID, Todo: todo})
+	if err != nil {
+		return err
+	}
+	if err := s.db.Put(todoKey(todo.ID), data); err != nil {
+		return err
+	}
+	s.index.Add(todo.ID, todo.Text)
+	return nil
+}
+
+// GetTodos walks every key and filters by owner, since pogreb has no
+// secondary index on user ID. pogreb's iteration order is unspecified
+// (it's a hash store), so newest-first is produced by sorting on ID
+// rather than relying on it, matching the sqlite backend's ordering.
+func (s *Store) GetTodos(userID int) ([]models.Todo, error) {
+	var todos []models.Todo
+
+	it := s.db.Items()
+	for {
+		_, value, err := it.Next()
+		if errors.Is(err, pogreb.ErrIterationDone) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("iterating todos: %w", err)
+		}
+
+		var rec record
+		if err := json.Unmarshal(value, &rec); err != nil {
+			continue
+		}
+		if rec.UserID == userID {
+			todos = append(todos, rec.Todo)
+		}
+	}
+
+	sort.Slice(todos, func(i, j int) bool { return todos[i].ID > todos[j].ID })
+
+	return todos, nil
+}
+
+func (s *Store) getOwned(userID, id int) (record, error) {
+	data, err := s.db.Get(todoKey(id))
+	if err != nil || data == nil {
+		return record{}, errors.New("todo not found")
+	}
+	var rec record
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return record{}, err
+	}
+	if rec.UserID != userID {
+		return record{}, errors.New("todo not found")
+	}
+	return rec, nil
+}
+
+func (s *Store) ToggleTodo(userID, id int) error {
+	if id <= 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	rec, err := s.getOwned(userID, id)
+	if err != nil {
+		return err
+	}
+	rec.Todo.Completed = !rec.Todo.Completed
+
+	return s.put(userID, rec.Todo)
+}
+
+func (s *Store) DeleteTodo(userID, id int) error {
+	if id <= 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	if _, err := s.getOwned(userID, id); err != nil {
+		return err
+	}
+
+	if err := s.db.Delete(todoKey(id)); err != nil {
+		return err
+	}
+	s.index.Remove(id)
+	return nil
+}
+
+// SearchTodos returns userID's todos matching query, ranked by TF-IDF
+// relevance via the in-memory inverted index (see models/search). Unlike
+// sqlite's FTS5 index, this one isn't persisted to disk; rebuildFromDisk
+// reconstructs it from the pogreb file on startup.
+func (s *Store) SearchTodos(userID int, query string) ([]models.Todo, error) {
+	var todos []models.Todo
+	for _, result := range s.index.Search(query) {
+		rec, err := s.getOwned(userID, result.ID)
+		if err != nil {
+			continue // owned by someone else, or deleted since the index snapshot
+		}
+		todos = append(todos, rec.Todo)
+	}
+
+	return todos, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,199 @@
+// Package postgres is a Store implementation backed by PostgreSQL via
+// database/sql and lib/pq. It's reachable via DB_DRIVER=postgres through
+// models/factory.Open, configured with a DATABASE_URL DSN, and
+// implements only the base models.Store contract: no auth, search or
+// SSE events. The memory/bitcask/pogreb backends likewise lack auth and
+// SSE events, though they do have a query-only SearchTodos. The bundled HTTP server (cmd/server) requires those sqlite-only
+// features, so DB_DRIVER=postgres isn't usable there yet; this backend
+// is for embedders calling factory.Open (or NewStore) directly.
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"memo/internal/models"
+
+	_ "github.com/lib/pq"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+var _ models.Store = (*Store)(nil)
+
+// NewStore opens a connection pool to dsn (falling back to the
+// DATABASE_URL environment variable when dsn is empty) and runs any
+// pending migrations before returning.
+func NewStore(dsn string) (*Store, error) {
+	if dsn == "" {
+		dsn = os.Getenv("DATABASE_URL")
+		if dsn == "" {
+			return nil, errors.New("postgres: no DSN given and DATABASE_URL is not set")
+		}
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Configure connection pool. Postgres doesn't share sqlite's
+	// single-writer file-locking model, so these env vars are the
+	// primary tuning surface for this backend.
+	maxOpenConns := 10
+	maxIdleConns := 5
+	connMaxLifetime := time.Hour
+
+	if env := os.Getenv("DB_MAX_OPEN_CONNS"); env != "" {
+		if val, err := strconv.Atoi(env); err == nil && val > 0 {
+			maxOpenConns = val
+		}
+	}
+	if env := os.Getenv("DB_MAX_IDLE_CONNS"); env != "" {
+		if val, err := strconv.Atoi(env); err == nil && val > 0 {
+			maxIdleConns = val
+		}
+	}
+	if env := os.Getenv("DB_CONN_MAX_LIFETIME"); env != "" {
+		if val, err := time.ParseDuration(env); err == nil && val > 0 {
+			connMaxLifetime = val
+		}
+	}
+
+	db.SetMaxOpenConns(maxOpenConns)
+	db.SetMaxIdleConns(maxIdleConns)
+	db.SetConnMaxLifetime(connMaxLifetime)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func validateTodoText(text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return errors.New("todo text cannot be empty")
+	}
+	if len(text) > 500 {
+		return errors.New("todo text cannot exceed 500 characters")
+	}
+	return nil
+}
+
+func (s *Store) AddTodo(userID int, text string) (models.Todo, error) {
+	if err := validateTodoText(text); err != nil {
+		return models.Todo{}, err
+	}
+	text = strings.TrimSpace(text)
+
+	now := time.Now()
+	var id int
+	query := `INSERT INTO todos (user_id, text, completed, created_at) VALUES ($1, $2, $3, $4) RETURNING id`
+	if err := s.db.QueryRow(query, userID, text, false, now).Scan(&id); err != nil {
+		log.Printf("Error adding todo: %v", err)
+		return models.Todo{}, err
+	}
+
+	return models.Todo{
+		ID:        id,
+		Text:      text,
+		Completed: false,
+		CreatedAt: now,
+	}, nil
+}
+
+func (s *Store) GetTodos(userID int) ([]models.Todo, error) {
+	query := `SELECT id, text, completed, created_at FROM todos WHERE user_id = $1 ORDER BY created_at DESC`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		log.Printf("Error querying todos: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var todos []models.Todo
+	for rows.Next() {
+		var todo models.Todo
+		if err := rows.Scan(&todo.ID, &todo.Text, &todo.Completed, &todo.CreatedAt); err != nil {
+			log.Printf("Error scanning todo row: %v", err)
+			continue
+		}
+		todos = append(todos, todo)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("Error iterating todo rows: %v", err)
+		return nil, err
+	}
+
+	return todos, nil
+}
+
+func (s *Store) ToggleTodo(userID, id int) error {
+	if id <= 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	query := `UPDATE todos SET completed = NOT completed WHERE id = $1 AND user_id = $2`
+	result, err := s.db.Exec(query, id, userID)
+	if err != nil {
+		log.Printf("Error toggling todo %d: %v", id, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error getting rows affected: %v", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("todo not found")
+	}
+
+	return nil
+}
+
+func (s *Store) DeleteTodo(userID, id int) error {
+	if id <= 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	query := `DELETE FROM todos WHERE id = $1 AND user_id = $2`
+	result, err := s.db.Exec(query, id, userID)
+	if err != nil {
+		log.Printf("Error deleting todo %d: %v", id, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error getting rows affected: %v", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("todo not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"os"
+	"testing"
+
+	"memo/internal/models"
+	"memo/internal/models/storetest"
+)
+
+// testDSN returns the DSN to test against, skipping the calling test
+// when PG_TEST_DSN isn't set rather than failing: these tests need a
+// real Postgres server, which isn't available in every environment.
+func testDSN(t *testing.T) string {
+	t.Helper()
+
+	dsn := os.Getenv("PG_TEST_DSN")
+	if dsn == "" {
+		t.Skip("PG_TEST_DSN not set, skipping postgres tests")
+	}
+	return dsn
+}
+
+// reset clears out state left over from earlier test runs so each
+// sub-test starts from an empty table instead of needing its own
+// throwaway database.
+func reset(t *testing.T, store *Store) {
+	t.Helper()
+	if _, err := store.db.Exec(`TRUNCATE TABLE todos RESTART IDENTITY`); err != nil {
+		t.Fatalf("Failed to reset todos table: %v", err)
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	dsn := testDSN(t)
+
+	t.Run("connects and migrates", func(t *testing.T) {
+		store, err := NewStore(dsn)
+		if err != nil {
+			t.Fatalf("NewStore() error = %v", err)
+		}
+		defer store.Close()
+	})
+
+	t.Run("rejects a missing DSN", func(t *testing.T) {
+		os.Unsetenv("DATABASE_URL")
+		if _, err := NewStore(""); err == nil {
+			t.Error("Expected error when no DSN is given")
+		}
+	})
+
+	t.Run("handles an unreachable DSN", func(t *testing.T) {
+		if _, err := NewStore("postgres://nobody@127.0.0.1:1/nonexistent?sslmode=disable"); err == nil {
+			t.Fatal("Expected error for an unreachable server")
+		}
+	})
+}
+
+func TestStoreConformance(t *testing.T) {
+	dsn := testDSN(t)
+
+	storetest.Run(t, func(t *testing.T) (models.Store, func()) {
+		store, err := NewStore(dsn)
+		if err != nil {
+			t.Fatalf("NewStore() error = %v", err)
+		}
+		reset(t, store)
+
+		return store, func() { store.Close() }
+	})
+}
@@ -0,0 +1,196 @@
+// Package search is a small in-memory inverted index shared by the
+// non-SQL store backends (memory, bitcask, pogreb). SQLite has its own
+// FTS5-backed search (see models/sqlite); these backends don't have an
+// equivalent query engine to delegate to, so they maintain this index
+// themselves as todos are added, edited or removed.
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// stopwords are dropped during tokenization so they don't dominate term
+// frequency scoring or bloat the posting lists.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "in": true, "is": true, "it": true,
+	"of": true, "on": true, "or": true, "that": true, "the": true, "to": true,
+	"was": true, "with": true,
+}
+
+// tokenize lowercases text, splits on runs of non-alphanumeric
+// characters, and drops stopwords. It deliberately skips stemming: the
+// repo's other search path (sqlite's FTS5) doesn't stem either, and
+// keeping behavior consistent across backends matters more than recall.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if !stopwords[f] {
+			tokens = append(tokens, f)
+		}
+	}
+	return tokens
+}
+
+// Index is a term -> todoID -> term-frequency posting list, plus a
+// document-frequency count per term for TF-IDF scoring.
+type Index struct {
+	mu       sync.RWMutex
+	postings map[string]map[int]int
+	docFreq  map[string]int
+	docs     map[int]bool // indexed document IDs, for the IDF denominator
+}
+
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string]map[int]int),
+		docFreq:  make(map[string]int),
+		docs:     make(map[int]bool),
+	}
+}
+
+// Add indexes text under id, replacing any previous entry for id.
+func (idx *Index) Add(id int, text string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.removeLocked(id)
+	idx.docs[id] = true
+
+	tf := make(map[string]int)
+	for _, token := range tokenize(text) {
+		tf[token]++
+	}
+
+	for token, count := range tf {
+		if idx.postings[token] == nil {
+			idx.postings[token] = make(map[int]int)
+		}
+		idx.postings[token][id] = count
+		idx.docFreq[token]++
+	}
+}
+
+// Remove drops id from every posting list it appears in.
+func (idx *Index) Remove(id int) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.removeLocked(id)
+}
+
+func (idx *Index) removeLocked(id int) {
+	delete(idx.docs, id)
+	for token, posting := range idx.postings {
+		if _, ok := posting[id]; !ok {
+			continue
+		}
+		delete(posting, id)
+		idx.docFreq[token]--
+		if len(posting) == 0 {
+			delete(idx.postings, token)
+			delete(idx.docFreq, token)
+		}
+	}
+}
+
+// Result is a matched document ID with its relevance score.
+type Result struct {
+	ID    int
+	Score float64
+}
+
+// matchingPostings returns term's own posting list when term is itself
+// an indexed token; longer tokens containing it are then not merged in.
+// Otherwise it merges the posting lists of every indexed token containing
+// term as a substring, so a query term like "mil" matches a document
+// indexed under "milk".
+func (idx *Index) matchingPostings(term string) map[int]int {
+	if posting, ok := idx.postings[term]; ok {
+		return posting
+	}
+
+	var merged map[int]int
+	for token, posting := range idx.postings {
+		if !strings.Contains(token, term) {
+			continue
+		}
+		if merged == nil {
+			merged = make(map[int]int)
+		}
+		for id, tf := range posting {
+			merged[id] += tf
+		}
+	}
+	return merged
+}
+
+// Search tokenizes query the same way documents are indexed, intersects
+// the matching posting lists (AND semantics across terms, with each term
+// allowed to partially match as in matchingPostings), and scores each
+// candidate by summing each term's TF-IDF contribution. Results are
+// returned most-relevant first.
+func (idx *Index) Search(query string) []Result {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	terms := tokenize(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	candidates := make(map[int]float64)
+	for i, token := range terms {
+		posting := idx.matchingPostings(token)
+		if posting == nil {
+			return nil // term absent entirely means no document can match all terms
+		}
+
+		idf := idf(len(posting), len(idx.docs))
+
+		if i == 0 {
+			for id, tf := range posting {
+				candidates[id] = float64(tf) * idf
+			}
+			continue
+		}
+
+		for id := range candidates {
+			tf, ok := posting[id]
+			if !ok {
+				delete(candidates, id)
+				continue
+			}
+			candidates[id] += float64(tf) * idf
+		}
+	}
+
+	results := make([]Result, 0, len(candidates))
+	for id, score := range candidates {
+		results = append(results, Result{ID: id, Score: score})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID > results[j].ID
+	})
+
+	return results
+}
+
+// idf is a simple inverse-document-frequency weight, 1 + N/df with no
+// logarithm: rarer terms (low docFreq relative to the corpus) score higher.
+func idf(docFreq, corpusSize int) float64 {
+	if docFreq == 0 || corpusSize == 0 {
+		return 0
+	}
+	return 1 + float64(corpusSize)/float64(docFreq)
+}
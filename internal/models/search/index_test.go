@@ -0,0 +1,92 @@
+package search
+
+import "testing"
+
+func ids(results []Result) []int {
+	out := make([]int, len(results))
+	for i, r := range results {
+		out[i] = r.ID
+	}
+	return out
+}
+
+func contains(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestIndexSearch(t *testing.T) {
+	idx := NewIndex()
+	idx.Add(1, "Buy milk and eggs")
+	idx.Add(2, "Walk the dog")
+	idx.Add(3, "Finish the report")
+
+	t.Run("matches a single term", func(t *testing.T) {
+		results := idx.Search("milk")
+		if len(results) != 1 || results[0].ID != 1 {
+			t.Errorf("Expected to find doc 1, got %+v", results)
+		}
+	})
+
+	t.Run("matches a partial word", func(t *testing.T) {
+		results := idx.Search("mil")
+		if len(results) != 1 || results[0].ID != 1 {
+			t.Errorf("Expected \"mil\" to match \"milk\" in doc 1, got %+v", results)
+		}
+	})
+
+	t.Run("matches multiple terms with AND semantics", func(t *testing.T) {
+		results := idx.Search("buy eggs")
+		if len(results) != 1 || results[0].ID != 1 {
+			t.Errorf("Expected to find doc 1, got %+v", results)
+		}
+	})
+
+	t.Run("returns nothing for unmatched terms", func(t *testing.T) {
+		if results := idx.Search("spaceship"); len(results) != 0 {
+			t.Errorf("Expected no results, got %+v", results)
+		}
+	})
+
+	t.Run("drops stopwords from the query", func(t *testing.T) {
+		results := idx.Search("the report")
+		if len(results) != 1 || results[0].ID != 3 {
+			t.Errorf("Expected to find doc 3, got %+v", results)
+		}
+	})
+}
+
+func TestIndexUpdatesAfterRemove(t *testing.T) {
+	idx := NewIndex()
+	idx.Add(1, "Buy milk")
+	idx.Add(2, "Buy bread")
+
+	idx.Remove(1)
+
+	results := idx.Search("milk")
+	if len(results) != 0 {
+		t.Errorf("Expected removed doc to no longer match, got %+v", results)
+	}
+
+	results = idx.Search("buy")
+	if !contains(ids(results), 2) || contains(ids(results), 1) {
+		t.Errorf("Expected only doc 2 to match, got %+v", results)
+	}
+}
+
+func TestIndexReAddReplacesPreviousEntry(t *testing.T) {
+	idx := NewIndex()
+	idx.Add(1, "Buy milk")
+	idx.Add(1, "Walk the dog")
+
+	if results := idx.Search("milk"); len(results) != 0 {
+		t.Errorf("Expected stale terms to be gone after re-add, got %+v", results)
+	}
+	if results := idx.Search("dog"); len(results) != 1 || results[0].ID != 1 {
+		t.Errorf("Expected doc 1 to match its new text, got %+v", results)
+	}
+}
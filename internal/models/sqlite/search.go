@@ -0,0 +1,182 @@
+package sqlite
+
+import (
+	"log"
+	"strings"
+
+	"memo/internal/models"
+)
+
+// hasFTS5 reports whether the SQLite build backing db has the FTS5
+// extension compiled in, by inspecting PRAGMA compile_options.
+func (s *Store) hasFTS5() bool {
+	rows, err := s.db.Query(`PRAGMA compile_options`)
+	if err != nil {
+		log.Printf("Error reading compile_options: %v", err)
+		return false
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var option string
+		if err := rows.Scan(&option); err != nil {
+			continue
+		}
+		if strings.EqualFold(option, "ENABLE_FTS5") {
+			return true
+		}
+	}
+	return false
+}
+
+// initFTS creates the todos_fts virtual table and the triggers that keep
+// it in sync with todos. If the SQLite build lacks FTS5, it logs a
+// warning and leaves ftsEnabled false so SearchTodos falls back to LIKE.
+func (s *Store) initFTS() {
+	if !s.hasFTS5() {
+		log.Printf("Warning: SQLite build lacks FTS5; falling back to LIKE-based search")
+		s.ftsEnabled = false
+		return
+	}
+
+	query := `
+	CREATE VIRTUAL TABLE IF NOT EXISTS todos_fts USING fts5(
+		text, content='todos', content_rowid='id'
+	);
+
+	CREATE TRIGGER IF NOT EXISTS todos_fts_ai AFTER INSERT ON todos BEGIN
+		INSERT INTO todos_fts(rowid, text) VALUES (new.id, new.text);
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS todos_fts_ad AFTER DELETE ON todos BEGIN
+		INSERT INTO todos_fts(todos_fts, rowid, text) VALUES ('delete', old.id, old.text);
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS todos_fts_au AFTER UPDATE ON todos BEGIN
+		INSERT INTO todos_fts(todos_fts, rowid, text) VALUES ('delete', old.id, old.text);
+		INSERT INTO todos_fts(rowid, text) VALUES (new.id, new.text);
+	END;`
+
+	if _, err := s.db.Exec(query); err != nil {
+		log.Printf("Error setting up FTS5 index, falling back to LIKE-based search: %v", err)
+		s.ftsEnabled = false
+		return
+	}
+
+	s.ftsEnabled = true
+}
+
+// sanitizeFTSQuery neutralizes FTS5 query-syntax operators (", *, -, ^,
+// AND/OR/NOT, column filters, ...) by quoting every token as a literal
+// phrase, so a search for e.g. `foo -bar` looks for the words "foo" and
+// "-bar" rather than excluding "bar". A leading "?:" opts into passing
+// the remainder through unescaped for users who want raw FTS5 syntax.
+func sanitizeFTSQuery(query string) string {
+	if rest, ok := strings.CutPrefix(query, "?:"); ok {
+		return rest
+	}
+
+	fields := strings.Fields(query)
+	tokens := make([]string, 0, len(fields))
+	for _, f := range fields {
+		tokens = append(tokens, `"`+strings.ReplaceAll(f, `"`, `""`)+`"`)
+	}
+	return strings.Join(tokens, " ")
+}
+
+// SearchTodos returns userID's todos matching query, filtered and ordered
+// per opts. When the store's SQLite build lacks FTS5, it degrades to an
+// AND of per-term LIKE '%term%' scans over the todo text. An empty (or
+// all-whitespace) query skips the text match entirely and returns
+// userID's todos subject only to opts, since FTS5 rejects an empty
+// MATCH expression outright.
+func (s *Store) SearchTodos(userID int, query string, opts models.SearchOptions) ([]models.Todo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if opts.Limit <= 0 {
+		opts.Limit = 50
+	}
+
+	query = strings.TrimSpace(query)
+
+	var sqlQuery string
+	args := []interface{}{}
+	useRank := false
+
+	if query == "" {
+		sqlQuery = `
+		SELECT t.id, t.text, t.completed, t.created_at
+		FROM todos t
+		WHERE t.user_id = ?`
+		args = append(args, userID)
+	} else if s.ftsEnabled {
+		sqlQuery = `
+		SELECT t.id, t.text, t.completed, t.created_at
+		FROM todos_fts f
+		JOIN todos t ON t.id = f.rowid
+		WHERE f.todos_fts MATCH ? AND t.user_id = ?`
+		args = append(args, sanitizeFTSQuery(query), userID)
+		useRank = true
+	} else {
+		// LIKE has no notion of "match all these words" the way
+		// FTS5's MATCH does by default, so AND one LIKE clause per
+		// term together: otherwise "milk eggs" would only match
+		// todos containing that exact two-word phrase.
+		sqlQuery = `
+		SELECT t.id, t.text, t.completed, t.created_at
+		FROM todos t
+		WHERE t.user_id = ?`
+		args = append(args, userID)
+		for _, term := range strings.Fields(query) {
+			sqlQuery += " AND t.text LIKE ?"
+			args = append(args, "%"+term+"%")
+		}
+	}
+
+	if opts.Completed != nil {
+		sqlQuery += " AND t.completed = ?"
+		args = append(args, *opts.Completed)
+	}
+	if !opts.After.IsZero() {
+		sqlQuery += " AND t.created_at >= ?"
+		args = append(args, opts.After)
+	}
+	if !opts.Before.IsZero() {
+		sqlQuery += " AND t.created_at <= ?"
+		args = append(args, opts.Before)
+	}
+
+	if opts.OrderBy == "rank" && useRank {
+		sqlQuery += " ORDER BY f.rank"
+	} else {
+		sqlQuery += " ORDER BY t.created_at DESC"
+	}
+
+	sqlQuery += " LIMIT ? OFFSET ?"
+	args = append(args, opts.Limit, opts.Offset)
+
+	rows, err := s.db.Query(sqlQuery, args...)
+	if err != nil {
+		log.Printf("Error searching todos: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var todos []models.Todo
+	for rows.Next() {
+		var todo models.Todo
+		if err := rows.Scan(&todo.ID, &todo.Text, &todo.Completed, &todo.CreatedAt); err != nil {
+			log.Printf("Error scanning searched todo row: %v", err)
+			continue
+		}
+		todos = append(todos, todo)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Printf("Error iterating searched todo rows: %v", err)
+		return nil, err
+	}
+
+	return todos, nil
+}
@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"testing"
+
+	"memo/internal/models"
+)
+
+func TestSearchTodos(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	userID := registerTestUser(t, store, "search@example.com")
+
+	store.AddTodo(userID, "Buy milk and eggs")
+	store.AddTodo(userID, "Walk the dog")
+	done, _ := store.AddTodo(userID, "Finish the report")
+	store.ToggleTodo(userID, done.ID)
+
+	t.Run("matches on a single term", func(t *testing.T) {
+		results, err := store.SearchTodos(userID, "milk", models.SearchOptions{})
+		if err != nil {
+			t.Fatalf("SearchTodos() error = %v", err)
+		}
+		if len(results) != 1 || results[0].Text != "Buy milk and eggs" {
+			t.Errorf("Expected to find the milk todo, got %+v", results)
+		}
+	})
+
+	t.Run("matches multiple terms with AND semantics", func(t *testing.T) {
+		results, err := store.SearchTodos(userID, "milk eggs", models.SearchOptions{})
+		if err != nil {
+			t.Fatalf("SearchTodos() error = %v", err)
+		}
+		if len(results) != 1 {
+			t.Errorf("Expected 1 result, got %d", len(results))
+		}
+	})
+
+	t.Run("filters by completed", func(t *testing.T) {
+		completed := true
+		results, err := store.SearchTodos(userID, "report", models.SearchOptions{Completed: &completed})
+		if err != nil {
+			t.Fatalf("SearchTodos() error = %v", err)
+		}
+		if len(results) != 1 {
+			t.Errorf("Expected to find the completed report todo, got %+v", results)
+		}
+	})
+
+	t.Run("returns nothing for unmatched terms", func(t *testing.T) {
+		results, err := store.SearchTodos(userID, "spaceship", models.SearchOptions{})
+		if err != nil {
+			t.Fatalf("SearchTodos() error = %v", err)
+		}
+		if len(results) != 0 {
+			t.Errorf("Expected no results, got %d", len(results))
+		}
+	})
+
+	t.Run("does not leak another user's todos", func(t *testing.T) {
+		otherUserID := registerTestUser(t, store, "search-other@example.com")
+		store.AddTodo(otherUserID, "Buy milk for the office")
+
+		results, err := store.SearchTodos(userID, "milk", models.SearchOptions{})
+		if err != nil {
+			t.Fatalf("SearchTodos() error = %v", err)
+		}
+		if len(results) != 1 {
+			t.Errorf("Expected only the calling user's todo, got %d results", len(results))
+		}
+	})
+
+	t.Run("an empty query returns all of the user's todos instead of erroring", func(t *testing.T) {
+		results, err := store.SearchTodos(userID, "", models.SearchOptions{})
+		if err != nil {
+			t.Fatalf("SearchTodos() error = %v", err)
+		}
+		if len(results) != 3 {
+			t.Errorf("Expected all 3 of the user's todos, got %d", len(results))
+		}
+	})
+
+	t.Run("a whitespace-only query is treated as empty", func(t *testing.T) {
+		results, err := store.SearchTodos(userID, "   ", models.SearchOptions{})
+		if err != nil {
+			t.Fatalf("SearchTodos() error = %v", err)
+		}
+		if len(results) != 3 {
+			t.Errorf("Expected all 3 of the user's todos, got %d", len(results))
+		}
+	})
+}
+
+func TestSanitizeFTSQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"quotes plain words", "buy milk", `"buy" "milk"`},
+		{"escapes embedded quotes", `say "hi"`, `"say" """hi"""`},
+		{"passes through advanced mode raw", `?:buy AND milk`, "buy AND milk"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sanitizeFTSQuery(tt.query)
+			if got != tt.want {
+				t.Errorf("sanitizeFTSQuery(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
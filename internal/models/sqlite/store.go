@@ -0,0 +1,374 @@
+// Package sqlite is the default Store implementation, backed by SQLite
+// via database/sql. Beyond the base models.Store contract it also
+// provides multi-user auth, SSE event publishing, batching (in tx.go) and
+// (in search.go) FTS5 full-text search with filter options. Auth, SSE
+// events and batching exist only here; the memory, bitcask and pogreb
+// backends have a query-only SearchTodos, and postgres has no search.
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"memo/internal/auth"
+	"memo/internal/events"
+	"memo/internal/migrations"
+	"memo/internal/models"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type Store struct {
+	mu         sync.RWMutex
+	db         *sql.DB
+	broker     *events.Broker
+	ftsEnabled bool
+}
+
+var _ models.Store = (*Store)(nil)
+
+// Events returns the broker that publishes add/toggle/delete
+// notifications, for handlers that want to stream them over SSE.
+func (s *Store) Events() *events.Broker {
+	return s.broker
+}
+
+func NewStore(dbPath string) (*Store, error) {
+	// Use environment variable if dbPath is empty
+	if dbPath == "" {
+		dbPath = os.Getenv("DB_PATH")
+		if dbPath == "" {
+			dbPath = "todos.db" // default fallback
+		}
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Configure connection pool
+	maxOpenConns := 10
+	maxIdleConns := 5
+	connMaxLifetime := time.Hour
+
+	// Allow override via environment variables
+	if env := os.Getenv("DB_MAX_OPEN_CONNS"); env != "" {
+		if val, err := strconv.Atoi(env); err == nil && val > 0 {
+			maxOpenConns = val
+		}
+	}
+	if env := os.Getenv("DB_MAX_IDLE_CONNS"); env != "" {
+		if val, err := strconv.Atoi(env); err == nil && val > 0 {
+			maxIdleConns = val
+		}
+	}
+	if env := os.Getenv("DB_CONN_MAX_LIFETIME"); env != "" {
+		if val, err := time.ParseDuration(env); err == nil && val > 0 {
+			connMaxLifetime = val
+		}
+	}
+
+	db.SetMaxOpenConns(maxOpenConns)
+	db.SetMaxIdleConns(maxIdleConns)
+	db.SetConnMaxLifetime(connMaxLifetime)
+
+	store := &Store{db: db, broker: events.NewBroker()}
+	if err := migrations.Migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	store.initFTS()
+
+	return store, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// validateTodoText validates todo text input
+func validateTodoText(text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return errors.New("todo text cannot be empty")
+	}
+	if len(text) > 500 {
+		return errors.New("todo text cannot exceed 500 characters")
+	}
+	return nil
+}
+
+// validateEmail validates a user-supplied email address
+func validateEmail(email string) error {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return errors.New("email cannot be empty")
+	}
+	if !strings.Contains(email, "@") {
+		return errors.New("email is not valid")
+	}
+	return nil
+}
+
+// RegisterUser creates a new user for email and returns their bearer
+// token. Registering an email that already exists returns an error.
+func (s *Store) RegisterUser(email string) (string, error) {
+	if err := validateEmail(email); err != nil {
+		return "", err
+	}
+	email = strings.TrimSpace(email)
+
+	token, err := auth.GenerateToken()
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := `INSERT INTO users (email, token, created_at) VALUES (?, ?, ?)`
+	if _, err := s.db.Exec(query, email, token, time.Now()); err != nil {
+		log.Printf("Error registering user %s: %v", email, err)
+		return "", err
+	}
+
+	return token, nil
+}
+
+// LookupUserByToken resolves the user ID owning a bearer token.
+func (s *Store) LookupUserByToken(token string) (int, error) {
+	if token == "" {
+		return 0, errors.New("token cannot be empty")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var userID int
+	query := `SELECT id FROM users WHERE token = ?`
+	err := s.db.QueryRow(query, token).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, errors.New("invalid token")
+	}
+	if err != nil {
+		log.Printf("Error looking up token: %v", err)
+		return 0, err
+	}
+
+	return userID, nil
+}
+
+func (s *Store) AddTodo(userID int, text string) (models.Todo, error) {
+	// Validate input
+	if err := validateTodoText(text); err != nil {
+		return models.Todo{}, err
+	}
+
+	text = strings.TrimSpace(text)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := `INSERT INTO todos (user_id, text, completed, created_at) VALUES (?, ?, ?, ?)`
+	now := time.Now()
+
+	result, err := s.db.Exec(query, userID, text, false, now)
+	if err != nil {
+		log.Printf("Error adding todo: %v", err)
+		return models.Todo{}, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		log.Printf("Error getting last insert ID: %v", err)
+		return models.Todo{}, err
+	}
+
+	todo := models.Todo{
+		ID:        int(id),
+		Text:      text,
+		Completed: false,
+		CreatedAt: now,
+	}
+
+	s.broker.Publish(userID, events.Event{Type: events.EventAdd, Todo: todo})
+
+	return todo, nil
+}
+
+func (s *Store) GetTodos(userID int) ([]models.Todo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	query := `SELECT id, text, completed, created_at FROM todos WHERE user_id = ? ORDER BY created_at DESC`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		log.Printf("Error querying todos: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var todos []models.Todo
+	for rows.Next() {
+		var todo models.Todo
+		err := rows.Scan(&todo.ID, &todo.Text, &todo.Completed, &todo.CreatedAt)
+		if err != nil {
+			log.Printf("Error scanning todo row: %v", err)
+			continue
+		}
+		todos = append(todos, todo)
+	}
+
+	if err = rows.Err(); err != nil {
+		log.Printf("Error iterating todo rows: %v", err)
+		return nil, err
+	}
+
+	return todos, nil
+}
+
+// UpdateTodoText replaces the text of an existing todo owned by userID.
+func (s *Store) UpdateTodoText(userID, id int, text string) error {
+	if id <= 0 {
+		return errors.New("invalid todo ID")
+	}
+	if err := validateTodoText(text); err != nil {
+		return err
+	}
+	text = strings.TrimSpace(text)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := `UPDATE todos SET text = ? WHERE id = ? AND user_id = ?`
+	result, err := s.db.Exec(query, text, id, userID)
+	if err != nil {
+		log.Printf("Error updating todo %d: %v", id, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error getting rows affected: %v", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("todo not found")
+	}
+
+	return nil
+}
+
+func (s *Store) ToggleTodo(userID, id int) error {
+	if id <= 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := `UPDATE todos SET completed = NOT completed WHERE id = ? AND user_id = ?`
+	result, err := s.db.Exec(query, id, userID)
+	if err != nil {
+		log.Printf("Error toggling todo %d: %v", id, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error getting rows affected: %v", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("todo not found")
+	}
+
+	var todo models.Todo
+	row := s.db.QueryRow(`SELECT id, text, completed, created_at FROM todos WHERE id = ?`, id)
+	if err := row.Scan(&todo.ID, &todo.Text, &todo.Completed, &todo.CreatedAt); err != nil {
+		log.Printf("Error reloading toggled todo %d: %v", id, err)
+		return err
+	}
+
+	s.broker.Publish(userID, events.Event{Type: events.EventToggle, Todo: todo})
+
+	return nil
+}
+
+// SetCompleted sets the completed state of an existing todo owned by
+// userID to exactly completed, unlike ToggleTodo which always flips it.
+// Setting it to the state it's already in is a no-op (no query, no
+// event), so PATCH requests with an explicit "completed" value are
+// idempotent.
+func (s *Store) SetCompleted(userID, id int, completed bool) error {
+	if id <= 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var todo models.Todo
+	row := s.db.QueryRow(`SELECT id, text, completed, created_at FROM todos WHERE id = ? AND user_id = ?`, id, userID)
+	if err := row.Scan(&todo.ID, &todo.Text, &todo.Completed, &todo.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("todo not found")
+		}
+		log.Printf("Error loading todo %d: %v", id, err)
+		return err
+	}
+
+	if todo.Completed == completed {
+		return nil
+	}
+
+	query := `UPDATE todos SET completed = ? WHERE id = ? AND user_id = ?`
+	if _, err := s.db.Exec(query, completed, id, userID); err != nil {
+		log.Printf("Error setting todo %d completed=%v: %v", id, completed, err)
+		return err
+	}
+	todo.Completed = completed
+
+	s.broker.Publish(userID, events.Event{Type: events.EventToggle, Todo: todo})
+
+	return nil
+}
+
+func (s *Store) DeleteTodo(userID, id int) error {
+	if id <= 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := `DELETE FROM todos WHERE id = ? AND user_id = ?`
+	result, err := s.db.Exec(query, id, userID)
+	if err != nil {
+		log.Printf("Error deleting todo %d: %v", id, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error getting rows affected: %v", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("todo not found")
+	}
+
+	s.broker.Publish(userID, events.Event{Type: events.EventDelete, Todo: models.Todo{ID: id}})
+
+	return nil
+}
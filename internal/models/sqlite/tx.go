@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"memo/internal/events"
+	"memo/internal/models"
+)
+
+// Tx is a single atomic batch of writes against a Store, wrapping a
+// *sql.Tx. Unlike the Store methods it mirrors, Tx operations don't
+// publish SSE events as they happen — an uncommitted write hasn't
+// really occurred yet — so the caller should treat Commit as the point
+// the batch becomes visible, and use BatchAdd (which does publish, once
+// the commit succeeds) rather than a hand-rolled Tx loop if live clients
+// need to see the result.
+type Tx struct {
+	store *Store
+	tx    *sql.Tx
+}
+
+// Begin starts a transaction, holding the Store's write lock for its
+// duration so it can't interleave with concurrent Store method calls.
+// Every Tx must be concluded with Commit or Rollback.
+func (s *Store) Begin() (*Tx, error) {
+	s.mu.Lock()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	return &Tx{store: s, tx: tx}, nil
+}
+
+func (t *Tx) Commit() error {
+	defer t.store.mu.Unlock()
+	return t.tx.Commit()
+}
+
+func (t *Tx) Rollback() error {
+	defer t.store.mu.Unlock()
+	return t.tx.Rollback()
+}
+
+func (t *Tx) AddTodo(userID int, text string) (models.Todo, error) {
+	if err := validateTodoText(text); err != nil {
+		return models.Todo{}, err
+	}
+	text = strings.TrimSpace(text)
+
+	query := `INSERT INTO todos (user_id, text, completed, created_at) VALUES (?, ?, ?, ?)`
+	now := time.Now()
+
+	result, err := t.tx.Exec(query, userID, text, false, now)
+	if err != nil {
+		return models.Todo{}, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return models.Todo{}, err
+	}
+
+	return models.Todo{ID: int(id), Text: text, Completed: false, CreatedAt: now}, nil
+}
+
+func (t *Tx) ToggleTodo(userID, id int) error {
+	if id <= 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	query := `UPDATE todos SET completed = NOT completed WHERE id = ? AND user_id = ?`
+	result, err := t.tx.Exec(query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("todo not found")
+	}
+
+	return nil
+}
+
+func (t *Tx) DeleteTodo(userID, id int) error {
+	if id <= 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	query := `DELETE FROM todos WHERE id = ? AND user_id = ?`
+	result, err := t.tx.Exec(query, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("todo not found")
+	}
+
+	return nil
+}
+
+// BatchAdd validates every text up front, then inserts all of them in a
+// single transaction: one fsync instead of one per todo. On success it
+// publishes an EventAdd for each new todo, same as AddTodo would.
+func (s *Store) BatchAdd(userID int, texts []string) ([]models.Todo, error) {
+	for _, text := range texts {
+		if err := validateTodoText(text); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	todos := make([]models.Todo, 0, len(texts))
+	for _, text := range texts {
+		todo, err := tx.AddTodo(userID, text)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		todos = append(todos, todo)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Error committing batch add: %v", err)
+		return nil, err
+	}
+
+	for _, todo := range todos {
+		s.broker.Publish(userID, events.Event{Type: events.EventAdd, Todo: todo})
+	}
+
+	return todos, nil
+}
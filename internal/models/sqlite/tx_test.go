@@ -0,0 +1,91 @@
+package sqlite
+
+import "testing"
+
+func TestBatchAdd(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	userID := registerTestUser(t, store, "batch-add@example.com")
+
+	t.Run("inserts every text in one commit", func(t *testing.T) {
+		todos, err := store.BatchAdd(userID, []string{"First", "Second", "Third"})
+		if err != nil {
+			t.Fatalf("BatchAdd() error = %v", err)
+		}
+		if len(todos) != 3 {
+			t.Fatalf("Expected 3 todos, got %d", len(todos))
+		}
+
+		stored, _ := store.GetTodos(userID)
+		if len(stored) != 3 {
+			t.Errorf("Expected 3 todos in the store, got %d", len(stored))
+		}
+	})
+
+	t.Run("rejects the whole batch if any text is invalid", func(t *testing.T) {
+		before, _ := store.GetTodos(userID)
+
+		_, err := store.BatchAdd(userID, []string{"Valid", ""})
+		if err == nil {
+			t.Fatal("Expected error for invalid text in batch")
+		}
+
+		after, _ := store.GetTodos(userID)
+		if len(after) != len(before) {
+			t.Errorf("Expected no todos to be added, went from %d to %d", len(before), len(after))
+		}
+	})
+}
+
+func TestTxRollback(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	userID := registerTestUser(t, store, "tx-rollback@example.com")
+
+	tx, err := store.Begin()
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+
+	if _, err := tx.AddTodo(userID, "Should not persist"); err != nil {
+		t.Fatalf("Tx.AddTodo() error = %v", err)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+
+	todos, _ := store.GetTodos(userID)
+	if len(todos) != 0 {
+		t.Errorf("Expected rolled-back todo to not persist, got %d todos", len(todos))
+	}
+}
+
+func TestTxToggleAndDelete(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	userID := registerTestUser(t, store, "tx-ops@example.com")
+
+	keep, _ := store.AddTodo(userID, "Keep me")
+	gone, _ := store.AddTodo(userID, "Delete me")
+
+	tx, err := store.Begin()
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+
+	if err := tx.ToggleTodo(userID, keep.ID); err != nil {
+		t.Fatalf("Tx.ToggleTodo() error = %v", err)
+	}
+	if err := tx.DeleteTodo(userID, gone.ID); err != nil {
+		t.Fatalf("Tx.DeleteTodo() error = %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	todos, _ := store.GetTodos(userID)
+	if len(todos) != 1 || todos[0].ID != keep.ID || !todos[0].Completed {
+		t.Errorf("Expected only the toggled todo to remain, got %+v", todos)
+	}
+}
@@ -0,0 +1,253 @@
+// Package storetest is a behavioral conformance suite for models.Store
+// implementations. Every backend (sqlite, memory, bitcask, pogreb, ...)
+// plugs its constructor into Run so they're all held to the same
+// contract: validation rules, GetTodos ordering, toggling back and forth, the
+// "todo not found"/"invalid todo ID" error strings, and thread safety
+// under concurrent writes.
+package storetest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"memo/internal/models"
+)
+
+// Factory builds a fresh, empty Store for a single test and returns a
+// cleanup function to release whatever resources it used (temp files,
+// open handles, ...).
+type Factory func(t *testing.T) (models.Store, func())
+
+// Run executes the full conformance suite against the store produced by
+// factory. Each sub-test calls factory again, so backends don't need to
+// support being reset between cases.
+func Run(t *testing.T, factory Factory) {
+	t.Helper()
+
+	t.Run("AddTodo", func(t *testing.T) { testAddTodo(t, factory) })
+	t.Run("GetTodos", func(t *testing.T) { testGetTodos(t, factory) })
+	t.Run("ToggleTodo", func(t *testing.T) { testToggleTodo(t, factory) })
+	t.Run("DeleteTodo", func(t *testing.T) { testDeleteTodo(t, factory) })
+	t.Run("ConcurrentAccess", func(t *testing.T) { testConcurrentAccess(t, factory) })
+}
+
+func testAddTodo(t *testing.T, factory Factory) {
+	store, cleanup := factory(t)
+	defer cleanup()
+
+	t.Run("adds valid todo", func(t *testing.T) {
+		todo, err := store.AddTodo(1, "Test todo")
+		if err != nil {
+			t.Fatalf("AddTodo() error = %v", err)
+		}
+		if todo.ID == 0 {
+			t.Error("Expected todo ID to be set")
+		}
+		if todo.Text != "Test todo" {
+			t.Errorf("Expected text %q, got %q", "Test todo", todo.Text)
+		}
+		if todo.Completed {
+			t.Error("Expected new todo to be not completed")
+		}
+		if todo.CreatedAt.IsZero() {
+			t.Error("Expected CreatedAt to be set")
+		}
+	})
+
+	t.Run("rejects empty text", func(t *testing.T) {
+		if _, err := store.AddTodo(1, "   "); err == nil {
+			t.Error("Expected error for empty text")
+		}
+	})
+
+	t.Run("rejects text too long", func(t *testing.T) {
+		if _, err := store.AddTodo(1, strings.Repeat("a", 501)); err == nil {
+			t.Error("Expected error for text too long")
+		}
+	})
+
+	t.Run("trims whitespace", func(t *testing.T) {
+		todo, err := store.AddTodo(1, "  Test with spaces  ")
+		if err != nil {
+			t.Fatalf("AddTodo() error = %v", err)
+		}
+		if todo.Text != "Test with spaces" {
+			t.Errorf("Expected text to be trimmed, got %q", todo.Text)
+		}
+	})
+}
+
+func testGetTodos(t *testing.T, factory Factory) {
+	store, cleanup := factory(t)
+	defer cleanup()
+
+	t.Run("returns empty list initially", func(t *testing.T) {
+		todos, err := store.GetTodos(1)
+		if err != nil {
+			t.Fatalf("GetTodos() error = %v", err)
+		}
+		if len(todos) != 0 {
+			t.Errorf("Expected empty list, got %d todos", len(todos))
+		}
+	})
+
+	t.Run("returns todos newest first", func(t *testing.T) {
+		todo1, _ := store.AddTodo(1, "First todo")
+		todo2, _ := store.AddTodo(1, "Second todo")
+		todo3, _ := store.AddTodo(1, "Third todo")
+
+		todos, err := store.GetTodos(1)
+		if err != nil {
+			t.Fatalf("GetTodos() error = %v", err)
+		}
+		if len(todos) != 3 {
+			t.Fatalf("Expected 3 todos, got %d", len(todos))
+		}
+		if todos[0].ID != todo3.ID || todos[1].ID != todo2.ID || todos[2].ID != todo1.ID {
+			t.Errorf("Expected newest-first order, got %+v", todos)
+		}
+	})
+
+	t.Run("does not leak another user's todos", func(t *testing.T) {
+		store.AddTodo(2, "Someone else's todo")
+
+		todos, err := store.GetTodos(1)
+		if err != nil {
+			t.Fatalf("GetTodos() error = %v", err)
+		}
+		for _, todo := range todos {
+			if todo.Text == "Someone else's todo" {
+				t.Errorf("Expected not to see another user's todo, got %+v", todos)
+			}
+		}
+	})
+}
+
+func testToggleTodo(t *testing.T, factory Factory) {
+	store, cleanup := factory(t)
+	defer cleanup()
+
+	t.Run("toggles and a second toggle restores the original state", func(t *testing.T) {
+		todo, _ := store.AddTodo(1, "Test todo")
+
+		if err := store.ToggleTodo(1, todo.ID); err != nil {
+			t.Fatalf("ToggleTodo() error = %v", err)
+		}
+		todos, _ := store.GetTodos(1)
+		if !todos[0].Completed {
+			t.Error("Expected todo to be completed")
+		}
+
+		if err := store.ToggleTodo(1, todo.ID); err != nil {
+			t.Fatalf("ToggleTodo() error = %v", err)
+		}
+		todos, _ = store.GetTodos(1)
+		if todos[0].Completed {
+			t.Error("Expected todo to be back to not completed")
+		}
+	})
+
+	t.Run("returns error for non-existent todo", func(t *testing.T) {
+		err := store.ToggleTodo(1, 99999)
+		if err == nil || err.Error() != "todo not found" {
+			t.Errorf("Expected 'todo not found' error, got %v", err)
+		}
+	})
+
+	t.Run("returns error for invalid ID", func(t *testing.T) {
+		err := store.ToggleTodo(1, 0)
+		if err == nil || err.Error() != "invalid todo ID" {
+			t.Errorf("Expected 'invalid todo ID' error, got %v", err)
+		}
+	})
+
+	t.Run("returns not found for another user's todo", func(t *testing.T) {
+		todo, _ := store.AddTodo(2, "Someone else's todo")
+
+		err := store.ToggleTodo(1, todo.ID)
+		if err == nil || err.Error() != "todo not found" {
+			t.Errorf("Expected 'todo not found' error, got %v", err)
+		}
+	})
+}
+
+func testDeleteTodo(t *testing.T, factory Factory) {
+	store, cleanup := factory(t)
+	defer cleanup()
+
+	t.Run("deletes existing todo", func(t *testing.T) {
+		todo, _ := store.AddTodo(1, "Test todo")
+
+		if err := store.DeleteTodo(1, todo.ID); err != nil {
+			t.Fatalf("DeleteTodo() error = %v", err)
+		}
+		todos, _ := store.GetTodos(1)
+		if len(todos) != 0 {
+			t.Errorf("Expected 0 todos, got %d", len(todos))
+		}
+	})
+
+	t.Run("returns error for non-existent todo", func(t *testing.T) {
+		err := store.DeleteTodo(1, 99999)
+		if err == nil || err.Error() != "todo not found" {
+			t.Errorf("Expected 'todo not found' error, got %v", err)
+		}
+	})
+
+	t.Run("returns error for invalid ID", func(t *testing.T) {
+		err := store.DeleteTodo(1, -1)
+		if err == nil || err.Error() != "invalid todo ID" {
+			t.Errorf("Expected 'invalid todo ID' error, got %v", err)
+		}
+	})
+
+	t.Run("returns not found for another user's todo and leaves it intact", func(t *testing.T) {
+		todo, _ := store.AddTodo(2, "Someone else's todo")
+
+		err := store.DeleteTodo(1, todo.ID)
+		if err == nil || err.Error() != "todo not found" {
+			t.Errorf("Expected 'todo not found' error, got %v", err)
+		}
+
+		todos, _ := store.GetTodos(2)
+		if len(todos) != 1 {
+			t.Errorf("Expected other user's todo to remain, got %d todos", len(todos))
+		}
+	})
+}
+
+func testConcurrentAccess(t *testing.T, factory Factory) {
+	store, cleanup := factory(t)
+	defer cleanup()
+
+	const numGoroutines = 10
+	const todosPerGoroutine = 5
+
+	results := make(chan error, numGoroutines)
+	for i := 0; i < numGoroutines; i++ {
+		go func(prefix int) {
+			for j := 0; j < todosPerGoroutine; j++ {
+				if _, err := store.AddTodo(1, fmt.Sprintf("Todo %d-%d", prefix, j)); err != nil {
+					results <- err
+					return
+				}
+			}
+			results <- nil
+		}(i)
+	}
+
+	for i := 0; i < numGoroutines; i++ {
+		if err := <-results; err != nil {
+			t.Errorf("Concurrent add failed: %v", err)
+		}
+	}
+
+	todos, err := store.GetTodos(1)
+	if err != nil {
+		t.Fatalf("GetTodos() error = %v", err)
+	}
+	if want := numGoroutines * todosPerGoroutine; len(todos) != want {
+		t.Errorf("Expected %d todos, got %d", want, len(todos))
+	}
+}
@@ -3,26 +3,95 @@ package router
 import (
 	"html/template"
 	"net/http"
+	"strings"
 
+	"memo/internal/auth"
 	"memo/internal/handlers"
-	"memo/internal/models"
+	"memo/internal/middleware"
+	"memo/internal/models/sqlite"
 )
 
-func SetupRoutes(store *models.TodoStore, templates *template.Template) *http.ServeMux {
+// sessionCookieName mirrors handlers.sessionCookieName; duplicated here
+// rather than exported to keep the cookie name a router/handlers
+// implementation detail.
+const sessionCookieName = "session_token"
+
+// Authenticate resolves the caller's bearer token (from the Authorization
+// header, or the session cookie for browser/HTMX use), stashes the user ID
+// in the request context, and rejects unauthenticated requests with 401.
+func Authenticate(store *sqlite.Store, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := bearerToken(r)
+		if token == "" {
+			if cookie, err := r.Cookie(sessionCookieName); err == nil {
+				token = cookie.Value
+			}
+		}
+
+		if token == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		userID, err := store.LookupUserByToken(token)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next(w, r.WithContext(auth.WithUserID(r.Context(), userID)))
+	}
+}
+
+func bearerToken(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(header, prefix)
+}
+
+// SetupRoutes wires the application routes and wraps them in the shared
+// middleware pipeline, from the outside in: panic recovery, metrics,
+// access logging, and gzip compression. Metrics therefore cover logging,
+// gzip and the routes, but not the recoverer; they're exposed on /metrics.
+func SetupRoutes(store *sqlite.Store, templates *template.Template) http.Handler {
 	mux := http.NewServeMux()
-	
+
 	// Initialize handlers
 	todoHandler := handlers.NewTodoHandler(store, templates)
+	authHandler := handlers.NewAuthHandler(store)
 
 	// Static files
 	mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("static"))))
 
+	// Auth routes (unauthenticated)
+	mux.HandleFunc("/register", authHandler.RegisterHandler)
+	mux.HandleFunc("/login", authHandler.LoginHandler)
+
 	// Routes
-	mux.HandleFunc("/", todoHandler.IndexHandler)
-	mux.HandleFunc("/todos", todoHandler.TodosHandler)
-	mux.HandleFunc("/todos/add", todoHandler.AddTodoHandler)
-	mux.HandleFunc("/todos/toggle/", todoHandler.ToggleTodoHandler)
-	mux.HandleFunc("/todos/delete/", todoHandler.DeleteTodoHandler)
-
-	return mux
-}
\ No newline at end of file
+	mux.HandleFunc("/", Authenticate(store, todoHandler.IndexHandler))
+	mux.HandleFunc("/todos", Authenticate(store, todoHandler.TodosHandler))
+	mux.HandleFunc("/todos/add", Authenticate(store, todoHandler.AddTodoHandler))
+	mux.HandleFunc("/todos/toggle/", Authenticate(store, todoHandler.ToggleTodoHandler))
+	mux.HandleFunc("/todos/delete/", Authenticate(store, todoHandler.DeleteTodoHandler))
+	mux.HandleFunc("/todos/events", Authenticate(store, todoHandler.EventsHandler))
+	mux.HandleFunc("/todos/search", Authenticate(store, todoHandler.SearchHandler))
+
+	// JSON REST API
+	mux.HandleFunc("/api/v1/todos", Authenticate(store, todoHandler.APITodosHandler))
+	mux.HandleFunc("/api/v1/todos/search", Authenticate(store, todoHandler.SearchHandler))
+	mux.HandleFunc("/api/v1/todos/", Authenticate(store, todoHandler.APITodoHandler))
+
+	metrics := middleware.NewMetrics()
+	mux.Handle("/metrics", metrics.Handler())
+
+	var handler http.Handler = mux
+	handler = middleware.Gzip(handler)
+	handler = middleware.RequestLogger(handler)
+	handler = metrics.Middleware(handler)
+	handler = middleware.Recoverer(handler)
+
+	return handler
+}